@@ -0,0 +1,11 @@
+package accountant
+
+import "github.com/ethereum/go-ethereum/accounts/abi"
+
+var (
+	ABI *abi.ABI
+)
+
+func init() {
+	ABI, _ = AccountantMetaData.GetAbi()
+}
@@ -0,0 +1,398 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package accountant
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress goerrors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AccountantMetaData contains all meta data concerning the Accountant contract.
+var AccountantMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"base\",\"outputs\":[{\"internalType\":\"contractERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getRate\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"rate\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractERC20\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"getRateInQuote\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"rateInQuote\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractERC20\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"getRateInQuoteSafe\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"rateInQuote\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getRateSafe\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"rate\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"vault\",\"outputs\":[{\"internalType\":\"contractBoringVault\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// AccountantABI is the input ABI used to generate the binding from.
+// Deprecated: Use AccountantMetaData.ABI instead.
+var AccountantABI = AccountantMetaData.ABI
+
+// Accountant is an auto generated Go binding around an Ethereum contract.
+type Accountant struct {
+	AccountantCaller     // Read-only binding to the contract
+	AccountantTransactor // Write-only binding to the contract
+	AccountantFilterer   // Log filterer for contract events
+}
+
+// AccountantCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AccountantCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountantTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AccountantTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountantFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AccountantFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountantSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AccountantSession struct {
+	Contract     *Accountant       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// AccountantCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AccountantCallerSession struct {
+	Contract *AccountantCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// AccountantTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AccountantTransactorSession struct {
+	Contract     *AccountantTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// AccountantRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AccountantRaw struct {
+	Contract *Accountant // Generic contract binding to access the raw methods on
+}
+
+// AccountantCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AccountantCallerRaw struct {
+	Contract *AccountantCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AccountantTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AccountantTransactorRaw struct {
+	Contract *AccountantTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAccountant creates a new instance of Accountant, bound to a specific deployed contract.
+func NewAccountant(address common.Address, backend bind.ContractBackend) (*Accountant, error) {
+	contract, err := bindAccountant(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Accountant{AccountantCaller: AccountantCaller{contract: contract}, AccountantTransactor: AccountantTransactor{contract: contract}, AccountantFilterer: AccountantFilterer{contract: contract}}, nil
+}
+
+// NewAccountantCaller creates a new read-only instance of Accountant, bound to a specific deployed contract.
+func NewAccountantCaller(address common.Address, caller bind.ContractCaller) (*AccountantCaller, error) {
+	contract, err := bindAccountant(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountantCaller{contract: contract}, nil
+}
+
+// NewAccountantTransactor creates a new write-only instance of Accountant, bound to a specific deployed contract.
+func NewAccountantTransactor(address common.Address, transactor bind.ContractTransactor) (*AccountantTransactor, error) {
+	contract, err := bindAccountant(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountantTransactor{contract: contract}, nil
+}
+
+// NewAccountantFilterer creates a new log filterer instance of Accountant, bound to a specific deployed contract.
+func NewAccountantFilterer(address common.Address, filterer bind.ContractFilterer) (*AccountantFilterer, error) {
+	contract, err := bindAccountant(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountantFilterer{contract: contract}, nil
+}
+
+// bindAccountant binds a generic wrapper to an already deployed contract.
+func bindAccountant(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AccountantMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Accountant *AccountantRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Accountant.Contract.AccountantCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Accountant *AccountantRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Accountant.Contract.AccountantTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Accountant *AccountantRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Accountant.Contract.AccountantTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Accountant *AccountantCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Accountant.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Accountant *AccountantTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Accountant.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Accountant *AccountantTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Accountant.Contract.contract.Transact(opts, method, params...)
+}
+
+// Base is a free data retrieval call binding the contract method 0x5001f3b5.
+//
+// Solidity: function base() view returns(address)
+func (_Accountant *AccountantCaller) Base(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "base")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Base is a free data retrieval call binding the contract method 0x5001f3b5.
+//
+// Solidity: function base() view returns(address)
+func (_Accountant *AccountantSession) Base() (common.Address, error) {
+	return _Accountant.Contract.Base(&_Accountant.CallOpts)
+}
+
+// Base is a free data retrieval call binding the contract method 0x5001f3b5.
+//
+// Solidity: function base() view returns(address)
+func (_Accountant *AccountantCallerSession) Base() (common.Address, error) {
+	return _Accountant.Contract.Base(&_Accountant.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Accountant *AccountantCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Accountant *AccountantSession) Decimals() (uint8, error) {
+	return _Accountant.Contract.Decimals(&_Accountant.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Accountant *AccountantCallerSession) Decimals() (uint8, error) {
+	return _Accountant.Contract.Decimals(&_Accountant.CallOpts)
+}
+
+// GetRate is a free data retrieval call binding the contract method 0x679aefce.
+//
+// Solidity: function getRate() view returns(uint256 rate)
+func (_Accountant *AccountantCaller) GetRate(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "getRate")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetRate is a free data retrieval call binding the contract method 0x679aefce.
+//
+// Solidity: function getRate() view returns(uint256 rate)
+func (_Accountant *AccountantSession) GetRate() (*big.Int, error) {
+	return _Accountant.Contract.GetRate(&_Accountant.CallOpts)
+}
+
+// GetRate is a free data retrieval call binding the contract method 0x679aefce.
+//
+// Solidity: function getRate() view returns(uint256 rate)
+func (_Accountant *AccountantCallerSession) GetRate() (*big.Int, error) {
+	return _Accountant.Contract.GetRate(&_Accountant.CallOpts)
+}
+
+// GetRateInQuote is a free data retrieval call binding the contract method 0x1dcbb110.
+//
+// Solidity: function getRateInQuote(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantCaller) GetRateInQuote(opts *bind.CallOpts, quote common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "getRateInQuote", quote)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetRateInQuote is a free data retrieval call binding the contract method 0x1dcbb110.
+//
+// Solidity: function getRateInQuote(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantSession) GetRateInQuote(quote common.Address) (*big.Int, error) {
+	return _Accountant.Contract.GetRateInQuote(&_Accountant.CallOpts, quote)
+}
+
+// GetRateInQuote is a free data retrieval call binding the contract method 0x1dcbb110.
+//
+// Solidity: function getRateInQuote(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantCallerSession) GetRateInQuote(quote common.Address) (*big.Int, error) {
+	return _Accountant.Contract.GetRateInQuote(&_Accountant.CallOpts, quote)
+}
+
+// GetRateInQuoteSafe is a free data retrieval call binding the contract method 0x820973da.
+//
+// Solidity: function getRateInQuoteSafe(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantCaller) GetRateInQuoteSafe(opts *bind.CallOpts, quote common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "getRateInQuoteSafe", quote)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetRateInQuoteSafe is a free data retrieval call binding the contract method 0x820973da.
+//
+// Solidity: function getRateInQuoteSafe(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantSession) GetRateInQuoteSafe(quote common.Address) (*big.Int, error) {
+	return _Accountant.Contract.GetRateInQuoteSafe(&_Accountant.CallOpts, quote)
+}
+
+// GetRateInQuoteSafe is a free data retrieval call binding the contract method 0x820973da.
+//
+// Solidity: function getRateInQuoteSafe(address quote) view returns(uint256 rateInQuote)
+func (_Accountant *AccountantCallerSession) GetRateInQuoteSafe(quote common.Address) (*big.Int, error) {
+	return _Accountant.Contract.GetRateInQuoteSafe(&_Accountant.CallOpts, quote)
+}
+
+// GetRateSafe is a free data retrieval call binding the contract method 0x282a8700.
+//
+// Solidity: function getRateSafe() view returns(uint256 rate)
+func (_Accountant *AccountantCaller) GetRateSafe(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "getRateSafe")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetRateSafe is a free data retrieval call binding the contract method 0x282a8700.
+//
+// Solidity: function getRateSafe() view returns(uint256 rate)
+func (_Accountant *AccountantSession) GetRateSafe() (*big.Int, error) {
+	return _Accountant.Contract.GetRateSafe(&_Accountant.CallOpts)
+}
+
+// GetRateSafe is a free data retrieval call binding the contract method 0x282a8700.
+//
+// Solidity: function getRateSafe() view returns(uint256 rate)
+func (_Accountant *AccountantCallerSession) GetRateSafe() (*big.Int, error) {
+	return _Accountant.Contract.GetRateSafe(&_Accountant.CallOpts)
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Accountant *AccountantCaller) Vault(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Accountant.contract.Call(opts, &out, "vault")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Accountant *AccountantSession) Vault() (common.Address, error) {
+	return _Accountant.Contract.Vault(&_Accountant.CallOpts)
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Accountant *AccountantCallerSession) Vault() (common.Address, error) {
+	return _Accountant.Contract.Vault(&_Accountant.CallOpts)
+}
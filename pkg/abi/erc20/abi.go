@@ -0,0 +1,11 @@
+package erc20
+
+import "github.com/ethereum/go-ethereum/accounts/abi"
+
+var (
+	ABI *abi.ABI
+)
+
+func init() {
+	ABI, _ = ERC20MetaData.GetAbi()
+}
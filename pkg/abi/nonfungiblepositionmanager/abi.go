@@ -0,0 +1,11 @@
+package nonfungiblepositionmanager
+
+import "github.com/ethereum/go-ethereum/accounts/abi"
+
+var (
+	ABI *abi.ABI
+)
+
+func init() {
+	ABI, _ = NonfungiblePositionManagerMetaData.GetAbi()
+}
@@ -0,0 +1,11 @@
+package swaprouter
+
+import "github.com/ethereum/go-ethereum/accounts/abi"
+
+var (
+	ABI *abi.ABI
+)
+
+func init() {
+	ABI, _ = SwapRouterMetaData.GetAbi()
+}
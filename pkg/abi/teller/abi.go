@@ -0,0 +1,11 @@
+package teller
+
+import "github.com/ethereum/go-ethereum/accounts/abi"
+
+var (
+	ABI *abi.ABI
+)
+
+func init() {
+	ABI, _ = TellerMetaData.GetAbi()
+}
@@ -0,0 +1,336 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package teller
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress goerrors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TellerMetaData contains all meta data concerning the Teller contract.
+var TellerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"accountant\",\"outputs\":[{\"internalType\":\"contractAccountantWithRateProviders\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isPaused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractERC20\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"isSupported\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"shareLockPeriod\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"vault\",\"outputs\":[{\"internalType\":\"contractBoringVault\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// TellerABI is the input ABI used to generate the binding from.
+// Deprecated: Use TellerMetaData.ABI instead.
+var TellerABI = TellerMetaData.ABI
+
+// Teller is an auto generated Go binding around an Ethereum contract.
+type Teller struct {
+	TellerCaller     // Read-only binding to the contract
+	TellerTransactor // Write-only binding to the contract
+	TellerFilterer   // Log filterer for contract events
+}
+
+// TellerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TellerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TellerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TellerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TellerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TellerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TellerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TellerSession struct {
+	Contract     *Teller           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TellerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TellerCallerSession struct {
+	Contract *TellerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// TellerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TellerTransactorSession struct {
+	Contract     *TellerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TellerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TellerRaw struct {
+	Contract *Teller // Generic contract binding to access the raw methods on
+}
+
+// TellerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TellerCallerRaw struct {
+	Contract *TellerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TellerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TellerTransactorRaw struct {
+	Contract *TellerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTeller creates a new instance of Teller, bound to a specific deployed contract.
+func NewTeller(address common.Address, backend bind.ContractBackend) (*Teller, error) {
+	contract, err := bindTeller(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Teller{TellerCaller: TellerCaller{contract: contract}, TellerTransactor: TellerTransactor{contract: contract}, TellerFilterer: TellerFilterer{contract: contract}}, nil
+}
+
+// NewTellerCaller creates a new read-only instance of Teller, bound to a specific deployed contract.
+func NewTellerCaller(address common.Address, caller bind.ContractCaller) (*TellerCaller, error) {
+	contract, err := bindTeller(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TellerCaller{contract: contract}, nil
+}
+
+// NewTellerTransactor creates a new write-only instance of Teller, bound to a specific deployed contract.
+func NewTellerTransactor(address common.Address, transactor bind.ContractTransactor) (*TellerTransactor, error) {
+	contract, err := bindTeller(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TellerTransactor{contract: contract}, nil
+}
+
+// NewTellerFilterer creates a new log filterer instance of Teller, bound to a specific deployed contract.
+func NewTellerFilterer(address common.Address, filterer bind.ContractFilterer) (*TellerFilterer, error) {
+	contract, err := bindTeller(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TellerFilterer{contract: contract}, nil
+}
+
+// bindTeller binds a generic wrapper to an already deployed contract.
+func bindTeller(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TellerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Teller *TellerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Teller.Contract.TellerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Teller *TellerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Teller.Contract.TellerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Teller *TellerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Teller.Contract.TellerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Teller *TellerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Teller.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Teller *TellerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Teller.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Teller *TellerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Teller.Contract.contract.Transact(opts, method, params...)
+}
+
+// Accountant is a free data retrieval call binding the contract method 0x4fb3ccc5.
+//
+// Solidity: function accountant() view returns(address)
+func (_Teller *TellerCaller) Accountant(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Teller.contract.Call(opts, &out, "accountant")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Accountant is a free data retrieval call binding the contract method 0x4fb3ccc5.
+//
+// Solidity: function accountant() view returns(address)
+func (_Teller *TellerSession) Accountant() (common.Address, error) {
+	return _Teller.Contract.Accountant(&_Teller.CallOpts)
+}
+
+// Accountant is a free data retrieval call binding the contract method 0x4fb3ccc5.
+//
+// Solidity: function accountant() view returns(address)
+func (_Teller *TellerCallerSession) Accountant() (common.Address, error) {
+	return _Teller.Contract.Accountant(&_Teller.CallOpts)
+}
+
+// IsPaused is a free data retrieval call binding the contract method 0xb187bd26.
+//
+// Solidity: function isPaused() view returns(bool)
+func (_Teller *TellerCaller) IsPaused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Teller.contract.Call(opts, &out, "isPaused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsPaused is a free data retrieval call binding the contract method 0xb187bd26.
+//
+// Solidity: function isPaused() view returns(bool)
+func (_Teller *TellerSession) IsPaused() (bool, error) {
+	return _Teller.Contract.IsPaused(&_Teller.CallOpts)
+}
+
+// IsPaused is a free data retrieval call binding the contract method 0xb187bd26.
+//
+// Solidity: function isPaused() view returns(bool)
+func (_Teller *TellerCallerSession) IsPaused() (bool, error) {
+	return _Teller.Contract.IsPaused(&_Teller.CallOpts)
+}
+
+// IsSupported is a free data retrieval call binding the contract method 0x4f129c53.
+//
+// Solidity: function isSupported(address ) view returns(bool)
+func (_Teller *TellerCaller) IsSupported(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Teller.contract.Call(opts, &out, "isSupported", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsSupported is a free data retrieval call binding the contract method 0x4f129c53.
+//
+// Solidity: function isSupported(address ) view returns(bool)
+func (_Teller *TellerSession) IsSupported(arg0 common.Address) (bool, error) {
+	return _Teller.Contract.IsSupported(&_Teller.CallOpts, arg0)
+}
+
+// IsSupported is a free data retrieval call binding the contract method 0x4f129c53.
+//
+// Solidity: function isSupported(address ) view returns(bool)
+func (_Teller *TellerCallerSession) IsSupported(arg0 common.Address) (bool, error) {
+	return _Teller.Contract.IsSupported(&_Teller.CallOpts, arg0)
+}
+
+// ShareLockPeriod is a free data retrieval call binding the contract method 0x9fdb11b6.
+//
+// Solidity: function shareLockPeriod() view returns(uint64)
+func (_Teller *TellerCaller) ShareLockPeriod(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _Teller.contract.Call(opts, &out, "shareLockPeriod")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ShareLockPeriod is a free data retrieval call binding the contract method 0x9fdb11b6.
+//
+// Solidity: function shareLockPeriod() view returns(uint64)
+func (_Teller *TellerSession) ShareLockPeriod() (uint64, error) {
+	return _Teller.Contract.ShareLockPeriod(&_Teller.CallOpts)
+}
+
+// ShareLockPeriod is a free data retrieval call binding the contract method 0x9fdb11b6.
+//
+// Solidity: function shareLockPeriod() view returns(uint64)
+func (_Teller *TellerCallerSession) ShareLockPeriod() (uint64, error) {
+	return _Teller.Contract.ShareLockPeriod(&_Teller.CallOpts)
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Teller *TellerCaller) Vault(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Teller.contract.Call(opts, &out, "vault")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Teller *TellerSession) Vault() (common.Address, error) {
+	return _Teller.Contract.Vault(&_Teller.CallOpts)
+}
+
+// Vault is a free data retrieval call binding the contract method 0xfbfa77cf.
+//
+// Solidity: function vault() view returns(address)
+func (_Teller *TellerCallerSession) Vault() (common.Address, error) {
+	return _Teller.Contract.Vault(&_Teller.CallOpts)
+}
@@ -0,0 +1,13 @@
+package blockstream
+
+import "time"
+
+const (
+	DefaultWindowSize = 128
+	DefaultInterval   = 2 * time.Second
+)
+
+const (
+	EventTypeBlock EventType = "block"
+	EventTypeReorg EventType = "reorg"
+)
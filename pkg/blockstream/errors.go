@@ -0,0 +1,7 @@
+package blockstream
+
+import "errors"
+
+var (
+	ErrReorgTooDeep = errors.New("reorg is deeper than the canonical window")
+)
@@ -0,0 +1,242 @@
+// Package blockstream follows the canonical chain through an EthClientAdapter and reports reorgs
+package blockstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+// Streamer walks the chain header by header and emits every canonical block with its logs.
+// It keeps a window of the last emitted blocks, a header whose parent is not the last emitted
+// block is a reorg: the replaced blocks are reported in a reorg event before the new chain
+// is emitted from the fork point
+type Streamer struct {
+	chainID   int64
+	ethClient adapter.EthClientAdapter
+	config    Config
+
+	window []*types.Block
+	next   *big.Int
+}
+
+func NewStreamer(chainID int64, ethClient adapter.EthClientAdapter, config Config) *Streamer {
+	if config.WindowSize <= 0 {
+		config.WindowSize = DefaultWindowSize
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	return &Streamer{
+		chainID:   chainID,
+		ethClient: ethClient,
+		config:    config,
+	}
+}
+
+// Run polls the chain and sends its events until ctx is done or a reorg deeper than the window is found
+func (s *Streamer) Run(ctx context.Context, events chan<- Event) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Poll(ctx, events)
+		if errors.Is(err, ErrReorgTooDeep) {
+			return err
+		}
+		if err != nil && ctx.Err() == nil {
+			logger.Warnf(ctx, "[Block Streamer] Poll chainID %d error: %s", s.chainID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll sends the events of the blocks up to the current head
+func (s *Streamer) Poll(ctx context.Context, events chan<- Event) error {
+	head, err := s.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if s.next == nil {
+		s.next = new(big.Int).Set(head.Number)
+		if s.config.StartBlock != nil {
+			s.next.Set(s.config.StartBlock)
+		}
+	}
+
+	// a reorg to a chain that is not longer only shows as a different head at a height already emitted
+	if block := s.blockAt(head.Number); block != nil && block.Hash != head.Hash {
+		if err := s.reorg(ctx, head, events); err != nil {
+			return err
+		}
+	}
+
+	for s.next.Cmp(head.Number) <= 0 {
+		header, err := s.ethClient.HeaderByNumber(ctx, s.next)
+		if err != nil {
+			return err
+		}
+
+		if last := s.last(); last != nil && header.ParentHash != last.Hash {
+			err = s.reorg(ctx, header, events)
+		} else {
+			err = s.emitBlock(ctx, header, common.Hash{}, events)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reorg walks the new chain back from header to the fork point in the window, sends a reorg
+// event for the replaced blocks and emits the new chain up to header
+func (s *Streamer) reorg(ctx context.Context, header *types.Header, events chan<- Event) error {
+	newChain := []*types.Header{header}
+	parentHash := header.ParentHash
+
+	fork := -1
+	i := int(new(big.Int).Sub(header.Number, s.window[0].Number).Int64()) - 1
+	for ; i >= 0; i-- {
+		if s.window[i].Hash == parentHash {
+			fork = i
+			break
+		}
+
+		parent, err := s.ethClient.HeaderByHash(ctx, parentHash)
+		if err != nil {
+			return err
+		}
+		newChain = append(newChain, parent)
+		parentHash = parent.ParentHash
+	}
+
+	if fork < 0 {
+		return fmt.Errorf("%w: chainID %d, block %s, window of %d blocks", ErrReorgTooDeep, s.chainID, header.Number, len(s.window))
+	}
+
+	reorg := &Reorg{
+		ForkBlock: toHeader(s.window[fork]),
+		Removed:   make([]*types.Block, 0, len(s.window)-fork-1),
+	}
+	replaced := make(map[uint64]common.Hash, len(s.window)-fork-1)
+	for j := len(s.window) - 1; j > fork; j-- {
+		reorg.Removed = append(reorg.Removed, markRemoved(s.window[j]))
+		replaced[s.window[j].Number.Uint64()] = s.window[j].Hash
+	}
+
+	logger.Warnf(ctx, "[Block Streamer] Reorg detected on chainID %d at block %s, %d blocks replaced",
+		s.chainID, reorg.ForkBlock.Number, len(reorg.Removed))
+
+	s.window = s.window[:fork+1]
+	s.next = new(big.Int).Add(reorg.ForkBlock.Number, big.NewInt(1))
+
+	if err := send(ctx, events, Event{Type: EventTypeReorg, Reorg: reorg}); err != nil {
+		return err
+	}
+
+	for j := len(newChain) - 1; j >= 0; j-- {
+		if err := s.emitBlock(ctx, newChain[j], replaced[newChain[j].Number.Uint64()], events); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emitBlock fetches the logs of header, appends the block to the window and sends it
+func (s *Streamer) emitBlock(ctx context.Context, header *types.Header, reorgedHash common.Hash, events chan<- Event) error {
+	logs, err := s.ethClient.FilterLogs(ctx, types.FilterQuery{
+		BlockHash: &header.Hash,
+		Addresses: s.config.Addresses,
+		Topics:    s.config.Topics,
+	})
+	if err != nil {
+		return err
+	}
+
+	block := &types.Block{
+		Number:      header.Number,
+		Hash:        header.Hash,
+		Timestamp:   header.Time,
+		ParentHash:  header.ParentHash,
+		ReorgedHash: reorgedHash,
+		Logs:        logs,
+	}
+
+	s.window = append(s.window, block)
+	if len(s.window) > s.config.WindowSize {
+		s.window = s.window[len(s.window)-s.config.WindowSize:]
+	}
+	s.next = new(big.Int).Add(header.Number, big.NewInt(1))
+
+	return send(ctx, events, Event{Type: EventTypeBlock, Block: block})
+}
+
+func (s *Streamer) last() *types.Block {
+	if len(s.window) == 0 {
+		return nil
+	}
+
+	return s.window[len(s.window)-1]
+}
+
+// blockAt returns the emitted block at number if it is still in the window
+func (s *Streamer) blockAt(number *big.Int) *types.Block {
+	if len(s.window) == 0 {
+		return nil
+	}
+
+	i := new(big.Int).Sub(number, s.window[0].Number)
+	if i.Sign() < 0 || i.Cmp(big.NewInt(int64(len(s.window)))) >= 0 {
+		return nil
+	}
+
+	return s.window[i.Int64()]
+}
+
+func send(ctx context.Context, events chan<- Event, event Event) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case events <- event:
+		return nil
+	}
+}
+
+func toHeader(block *types.Block) *types.Header {
+	return &types.Header{
+		Hash:       block.Hash,
+		ParentHash: block.ParentHash,
+		Number:     block.Number,
+		Time:       block.Timestamp,
+	}
+}
+
+// markRemoved copies block with its logs marked as removed
+func markRemoved(block *types.Block) *types.Block {
+	removed := *block
+	removed.Logs = make([]types.Log, len(block.Logs))
+	for i, log := range block.Logs {
+		log.Removed = true
+		removed.Logs[i] = log
+	}
+
+	return &removed
+}
@@ -0,0 +1,212 @@
+package blockstream_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/blockstream"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+var emitter = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// mine mines n blocks on the fake, each with one log of emitter
+func mine(fake *adaptertest.Fake, n int) []*types.Header {
+	headers := make([]*types.Header, 0, n)
+	for i := 0; i < n; i++ {
+		header := fake.Mine()
+		fake.AddLogs(types.Log{Address: emitter, BlockNumber: header.Number.Uint64(), BlockHash: header.Hash})
+		headers = append(headers, header)
+	}
+
+	return headers
+}
+
+// fork replaces the chain above parent with n new blocks, each with one log of emitter
+func fork(fake *adaptertest.Fake, parent *types.Header, n int) []*types.Header {
+	headers := make([]*types.Header, 0, n)
+	for i := 0; i < n; i++ {
+		header := fake.AddHeader(&types.Header{
+			ParentHash: parent.Hash,
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			// a different time gives a different hash than the replaced block
+			Time: parent.Time + 13,
+		})
+		fake.AddLogs(types.Log{Address: emitter, BlockNumber: header.Number.Uint64(), BlockHash: header.Hash})
+		headers = append(headers, header)
+		parent = header
+	}
+
+	return headers
+}
+
+// poll polls the streamer once and returns the events it sent
+func poll(t *testing.T, streamer *blockstream.Streamer) ([]blockstream.Event, error) {
+	t.Helper()
+
+	events := make(chan blockstream.Event, 1024)
+	err := streamer.Poll(context.Background(), events)
+	close(events)
+
+	var sent []blockstream.Event
+	for event := range events {
+		sent = append(sent, event)
+	}
+
+	return sent, err
+}
+
+// assertBlocks checks that events are the blocks of headers, replacing the blocks of replaced at the same height
+func assertBlocks(t *testing.T, events []blockstream.Event, headers []*types.Header, replaced map[uint64]common.Hash) {
+	t.Helper()
+
+	if len(events) != len(headers) {
+		t.Fatalf("%d events, want the %d blocks", len(events), len(headers))
+	}
+
+	for i, event := range events {
+		if event.Type != blockstream.EventTypeBlock || event.Block.Hash != headers[i].Hash {
+			t.Fatalf("event %d = %+v, want block %s", i, event, headers[i].Number)
+		}
+		if event.Block.ReorgedHash != replaced[headers[i].Number.Uint64()] {
+			t.Fatalf("block %s replaced %s, want %s", headers[i].Number, event.Block.ReorgedHash, replaced[headers[i].Number.Uint64()])
+		}
+		if len(event.Block.Logs) != 1 || event.Block.Logs[0].BlockHash != headers[i].Hash {
+			t.Fatalf("block %s logs = %+v, want its log", headers[i].Number, event.Block.Logs)
+		}
+	}
+}
+
+// assertReorg checks that event is a reorg from forkBlock removing removed, newest first
+func assertReorg(t *testing.T, event blockstream.Event, forkBlock *types.Header, removed []*types.Header) {
+	t.Helper()
+
+	if event.Type != blockstream.EventTypeReorg || event.Reorg.ForkBlock.Hash != forkBlock.Hash {
+		t.Fatalf("event = %+v, want a reorg from block %s", event, forkBlock.Number)
+	}
+	if len(event.Reorg.Removed) != len(removed) {
+		t.Fatalf("reorg removed %d blocks, want %d", len(event.Reorg.Removed), len(removed))
+	}
+
+	for i, block := range event.Reorg.Removed {
+		want := removed[len(removed)-1-i]
+		if block.Hash != want.Hash {
+			t.Fatalf("removed block %d = %s, want %s", i, block.Number, want.Number)
+		}
+		if len(block.Logs) != 1 || !block.Logs[0].Removed {
+			t.Fatalf("removed block %s logs = %+v, want its log marked removed", block.Number, block.Logs)
+		}
+	}
+}
+
+func newStreamer(fake *adaptertest.Fake, windowSize int) *blockstream.Streamer {
+	return blockstream.NewStreamer(1, fake, blockstream.Config{
+		StartBlock: big.NewInt(1),
+		Addresses:  []common.Address{emitter},
+		WindowSize: windowSize,
+	})
+}
+
+func TestStreamerSingleBlockReorg(t *testing.T) {
+	fake := adaptertest.NewFake()
+	chain := mine(fake, 3)
+
+	streamer := newStreamer(fake, 0)
+	events, err := poll(t, streamer)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	assertBlocks(t, events, chain, nil)
+
+	// the head is replaced by a sibling, the chain does not get longer
+	reorged := fork(fake, chain[1], 1)
+
+	events, err = poll(t, streamer)
+	if err != nil {
+		t.Fatalf("Poll after the reorg: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("%d events after the reorg, want the reorg and the new head", len(events))
+	}
+	assertReorg(t, events[0], chain[1], chain[2:])
+	assertBlocks(t, events[1:], reorged, map[uint64]common.Hash{3: chain[2].Hash})
+}
+
+func TestStreamerMultiBlockReorg(t *testing.T) {
+	fake := adaptertest.NewFake()
+	chain := mine(fake, 5)
+
+	streamer := newStreamer(fake, 0)
+	if _, err := poll(t, streamer); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	// blocks 3 to 5 are replaced by a longer chain
+	reorged := fork(fake, chain[1], 4)
+
+	events, err := poll(t, streamer)
+	if err != nil {
+		t.Fatalf("Poll after the reorg: %v", err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("%d events after the reorg, want the reorg and the 4 new blocks", len(events))
+	}
+	assertReorg(t, events[0], chain[1], chain[2:])
+	assertBlocks(t, events[1:], reorged, map[uint64]common.Hash{
+		3: chain[2].Hash,
+		4: chain[3].Hash,
+		5: chain[4].Hash,
+	})
+
+	// the new chain is followed after the reorg
+	next := mine(fake, 1)
+	events, err = poll(t, streamer)
+	if err != nil {
+		t.Fatalf("Poll after the new chain: %v", err)
+	}
+	assertBlocks(t, events, next, nil)
+}
+
+func TestStreamerReorgDeeperThanWindow(t *testing.T) {
+	fake := adaptertest.NewFake()
+	chain := mine(fake, 6)
+
+	// the window keeps blocks 4 to 6
+	streamer := newStreamer(fake, 3)
+	if _, err := poll(t, streamer); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	// the fork point, block 2, left the window
+	fork(fake, chain[1], 5)
+
+	events, err := poll(t, streamer)
+	if !errors.Is(err, blockstream.ErrReorgTooDeep) {
+		t.Fatalf("Poll error = %v, want %v", err, blockstream.ErrReorgTooDeep)
+	}
+	if len(events) != 0 {
+		t.Fatalf("%d events sent for a reorg deeper than the window, want none", len(events))
+	}
+
+	// Run stops on the reorg instead of retrying
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := streamer.Run(ctx, make(chan blockstream.Event, 1024)); !errors.Is(err, blockstream.ErrReorgTooDeep) {
+		t.Fatalf("Run error = %v, want %v", err, blockstream.ErrReorgTooDeep)
+	}
+}
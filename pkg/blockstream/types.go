@@ -0,0 +1,56 @@
+package blockstream
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+type Config struct {
+	// StartBlock is the first block streamed, nil starts at the head
+	StartBlock *big.Int
+
+	// Addresses and Topics filter the logs attached to the blocks, as in types.FilterQuery
+	Addresses []common.Address
+	Topics    [][]common.Hash
+
+	// WindowSize is the number of canonical blocks kept to find the fork point of a reorg
+	WindowSize int
+
+	// Interval is the polling interval used when the streamer is caught up
+	Interval time.Duration
+}
+
+type EventType string
+
+// Event is either a new canonical block or a reorg that removed blocks emitted before
+type Event struct {
+	Type EventType
+
+	// Block is set for EventTypeBlock, its ReorgedHash is the hash of the block it replaced at the same height
+	Block *types.Block
+
+	// Reorg is set for EventTypeReorg
+	Reorg *Reorg
+}
+
+type Reorg struct {
+	// ForkBlock is the last block shared by the old and the new chain
+	ForkBlock *types.Header
+
+	// Removed are the replaced blocks from the newest to the oldest, their logs are marked Removed
+	Removed []*types.Block
+}
+
+// ReplacedHashes returns the hashes of the removed blocks
+func (r *Reorg) ReplacedHashes() []common.Hash {
+	hashes := make([]common.Hash, 0, len(r.Removed))
+	for _, block := range r.Removed {
+		hashes = append(hashes, block.Hash)
+	}
+
+	return hashes
+}
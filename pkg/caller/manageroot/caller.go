@@ -1,6 +1,7 @@
 package manageroot
 
 import (
+	"context"
 	"encoding/hex"
 
 	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
@@ -18,21 +19,18 @@ func NewManageRootCaller(rpcRegistry rpcregistry.IRegistry) *Caller {
 }
 
 func (c *Caller) GetManageRoot(target string, strategist common.Address, chainId int64) (string, error) {
+	return c.GetManageRootContext(context.Background(), target, strategist, chainId)
+}
+
+// GetManageRootContext is GetManageRoot bounded by ctx
+func (c *Caller) GetManageRootContext(ctx context.Context, target string, strategist common.Address, chainId int64) (string, error) {
 	rpcClient, err := c.rpcRegistry.GetRpcClient(chainId)
 	if err != nil {
 		return "", err
 	}
 
-	var root [32]byte
-	req := rpcClient.NewRequest()
-	req.AddCall(&ethrpc.Call{
-		ABI:    *manageroot.ABI,
-		Target: target,
-		Method: "manageRoot",
-		Params: []any{strategist},
-	}, []any{&root})
-
-	if _, err := req.TryBlockAndAggregate(); err != nil {
+	root, err := ethrpc.CallTyped[[32]byte](ctx, rpcClient, manageroot.ABI, target, "manageRoot", strategist)
+	if err != nil {
 		return "", err
 	}
 
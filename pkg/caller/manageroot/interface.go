@@ -1,9 +1,12 @@
 package manageroot
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type ICaller interface {
 	GetManageRoot(target string, strategist common.Address, chainId int64) (string, error)
+	GetManageRootContext(ctx context.Context, target string, strategist common.Address, chainId int64) (string, error)
 }
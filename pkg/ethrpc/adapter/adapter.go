@@ -1,9 +1,16 @@
 package adapter
 
 import (
+	"github.com/ethereum/go-ethereum/ethclient"
+
 	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/ethereum"
 )
 
 func New(url string) (EthClientAdapter, error) {
 	return ethereum.NewAdapter(url)
 }
+
+// NewFromClient creates an adapter on top of an already dialed client
+func NewFromClient(client *ethclient.Client) EthClientAdapter {
+	return ethereum.NewAdapterFromClient(client)
+}
@@ -0,0 +1,10 @@
+// Package adaptertest provides EthClientAdapter implementations for tests: one on a go-ethereum
+// simulated backend and a scripted fake, both serving the Multicall3 at MulticallAddress.
+//
+// The repository bundles the Multicall3 ABI but not its bytecode. The simulated backend deploys
+// MulticallCode, a Multicall3 compatible runtime assembled in Go, so its aggregates run in the EVM
+// like on a chain. The fake has no EVM and answers the calls of an aggregate one by one from its
+// scripted responses.
+//
+// The simulated backend runs an in-process go-ethereum node, its tests run with the others
+package adaptertest
@@ -0,0 +1,568 @@
+package adaptertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+const (
+	DefaultFakeGasPrice    = 1_000_000_000
+	DefaultFakeGasTipCap   = 1_000_000_000
+	DefaultFakeGasEstimate = 21000
+
+	fakeBlockTime = 12
+
+	// fakeHeadQueueSize is how many added headers are queued for the subscribers
+	fakeHeadQueueSize = 1024
+)
+
+var (
+	ErrNoResponse = errors.New("no response registered for call")
+)
+
+// Fake is a scripted EthClientAdapter. Calls are answered with the response registered for their
+// target and calldata whatever the block, calls to the Multicall3 at MulticallAddress are answered
+// call by call from the same responses. It starts with a genesis header and mines only on demand
+type Fake struct {
+	mu sync.Mutex
+
+	responses map[fakeCallKey]fakeResponse
+	calls     []types.CallMsg
+
+	headers   map[common.Hash]*types.Header
+	canonical []*types.Header
+	headFeed  event.Feed
+	headQueue chan *types.Header
+	logs      []types.Log
+
+	gasPrice    *big.Int
+	gasTipCap   *big.Int
+	gasEstimate uint64
+	feeHistory  *types.FeeHistory
+	nonces      map[common.Address]uint64
+
+	sent     []*gethtypes.Transaction
+	receipts map[common.Hash]*types.Receipt
+}
+
+type fakeCallKey struct {
+	to   common.Address
+	data string
+}
+
+type fakeResponse struct {
+	data []byte
+	err  error
+}
+
+func NewFake() *Fake {
+	genesis := &types.Header{
+		Number: big.NewInt(0),
+	}
+	genesis.Hash = fakeHeaderHash(genesis)
+
+	return &Fake{
+		responses:   make(map[fakeCallKey]fakeResponse),
+		headers:     map[common.Hash]*types.Header{genesis.Hash: genesis},
+		canonical:   []*types.Header{genesis},
+		gasPrice:    big.NewInt(DefaultFakeGasPrice),
+		gasTipCap:   big.NewInt(DefaultFakeGasTipCap),
+		gasEstimate: DefaultFakeGasEstimate,
+		nonces:      make(map[common.Address]uint64),
+		receipts:    make(map[common.Hash]*types.Receipt),
+	}
+}
+
+// NewRpcClient creates an ethrpc client on the fake with the Multicall3 and the parse middlewares,
+// options are applied after them
+func (f *Fake) NewRpcClient(options ...func(*ethrpc.Client)) *ethrpc.Client {
+	return ethrpc.NewClient(append([]func(*ethrpc.Client){
+		ethrpc.WithEthClientAdapter(f),
+		ethrpc.WithMulticall(MulticallAddress, multicall3.ABI),
+		ethrpc.WithRequestMiddlewares(ethrpc.ParseRequestMiddleware),
+		ethrpc.WithResponseMiddlewares(ethrpc.ParseResponseMiddleware),
+	}, options...)...)
+}
+
+// OnCall answers calls to target with data
+func (f *Fake) OnCall(target common.Address, data []byte, response []byte) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[fakeCallKey{to: target, data: hexutil.Encode(data)}] = fakeResponse{data: response}
+
+	return f
+}
+
+// OnCallError fails calls to target with data, a RevertError makes the call revert
+func (f *Fake) OnCallError(target common.Address, data []byte, err error) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[fakeCallKey{to: target, data: hexutil.Encode(data)}] = fakeResponse{err: err}
+
+	return f
+}
+
+// OnMethod answers calls of method with params to target with the packed outputs
+func (f *Fake) OnMethod(target common.Address, contractABI *abi.ABI, method string, params []interface{}, outputs ...interface{}) error {
+	data, err := contractABI.Pack(method, params...)
+	if err != nil {
+		return err
+	}
+
+	response, err := contractABI.Methods[method].Outputs.Pack(outputs...)
+	if err != nil {
+		return err
+	}
+
+	f.OnCall(target, data, response)
+
+	return nil
+}
+
+// Calls returns the calls received so far, the calls of an aggregate are not listed separately
+func (f *Fake) Calls() []types.CallMsg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calls := make([]types.CallMsg, len(f.calls))
+	copy(calls, f.calls)
+
+	return calls
+}
+
+// AddHeader makes header the head, replacing the canonical headers at and above its number so reorgs
+// can be scripted. A zero hash is derived from the number and parent hash. Subscribers receive the
+// headers in order from a queue of fakeHeadQueueSize headers, AddHeader only blocks while it is full
+func (f *Fake) AddHeader(header *types.Header) *types.Header {
+	if header.Hash == (common.Hash{}) {
+		header.Hash = fakeHeaderHash(header)
+	}
+
+	f.mu.Lock()
+	number := int(header.Number.Int64())
+	if number < len(f.canonical) {
+		f.canonical = f.canonical[:number]
+	}
+	for len(f.canonical) < number {
+		// the gap below the header is left without headers
+		f.canonical = append(f.canonical, nil)
+	}
+	f.canonical = append(f.canonical, header)
+	f.headers[header.Hash] = header
+	headQueue := f.headQueue
+	f.mu.Unlock()
+
+	if headQueue != nil {
+		headQueue <- header
+	}
+
+	return header
+}
+
+// Mine adds a child of the head and returns it
+func (f *Fake) Mine() *types.Header {
+	f.mu.Lock()
+	head := f.head()
+	f.mu.Unlock()
+
+	return f.AddHeader(&types.Header{
+		ParentHash: head.Hash,
+		Number:     new(big.Int).Add(head.Number, big.NewInt(1)),
+		Time:       head.Time + fakeBlockTime,
+	})
+}
+
+// AddLogs adds logs returned by FilterLogs
+func (f *Fake) AddLogs(logs ...types.Log) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.logs = append(f.logs, logs...)
+
+	return f
+}
+
+func (f *Fake) SetGasPrice(gasPrice *big.Int) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.gasPrice = gasPrice
+
+	return f
+}
+
+func (f *Fake) SetGasTipCap(gasTipCap *big.Int) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.gasTipCap = gasTipCap
+
+	return f
+}
+
+func (f *Fake) SetGasEstimate(gas uint64) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.gasEstimate = gas
+
+	return f
+}
+
+// SetFeeHistory replaces the fee history built from the gas price and tip cap
+func (f *Fake) SetFeeHistory(feeHistory *types.FeeHistory) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.feeHistory = feeHistory
+
+	return f
+}
+
+func (f *Fake) SetNonce(account common.Address, nonce uint64) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nonces[account] = nonce
+
+	return f
+}
+
+// SetReceipt sets the receipt of txHash, it replaces the successful receipt of a sent transaction
+func (f *Fake) SetReceipt(txHash common.Hash, receipt *types.Receipt) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.receipts[txHash] = receipt
+
+	return f
+}
+
+// SentTransactions returns the transactions sent so far
+func (f *Fake) SentTransactions() []*gethtypes.Transaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sent := make([]*gethtypes.Transaction, len(f.sent))
+	copy(sent, f.sent)
+
+	return sent
+}
+
+func (f *Fake) CallContract(_ context.Context, msg *types.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	header := f.head()
+	if blockNumber != nil && blockNumber.Sign() >= 0 {
+		header = f.headerByNumber(blockNumber)
+		if header == nil {
+			return nil, ethereum.NotFound
+		}
+	}
+
+	return f.call(msg, header)
+}
+
+func (f *Fake) CallContractAtHash(_ context.Context, msg *types.CallMsg, blockHash common.Hash) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	header, ok := f.headers[blockHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return f.call(msg, header)
+}
+
+// SubscribeNewHead subscribes to the headers added from now on
+func (f *Fake) SubscribeNewHead(_ context.Context, headerChannel chan<- *types.Header) (types.Subscription, error) {
+	sub := f.headFeed.Subscribe(headerChannel)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.headQueue == nil {
+		f.headQueue = make(chan *types.Header, fakeHeadQueueSize)
+		go f.deliverHeads(f.headQueue)
+	}
+
+	return sub, nil
+}
+
+// deliverHeads sends the queued headers to the subscribers, a slow subscriber delays the later headers
+func (f *Fake) deliverHeads(headQueue <-chan *types.Header) {
+	for header := range headQueue {
+		f.headFeed.Send(header)
+	}
+}
+
+// FilterLogs returns the added logs matching query, a block range is matched on the log block numbers
+// and skips the logs of blocks replaced by AddHeader like a node only returns canonical logs
+func (f *Fake) FilterLogs(_ context.Context, query types.FilterQuery) ([]types.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var logs []types.Log
+	for _, log := range f.logs {
+		if !matchLog(log, query) {
+			continue
+		}
+
+		if query.BlockHash == nil && log.BlockHash != (common.Hash{}) {
+			if header := f.headerByNumber(new(big.Int).SetUint64(log.BlockNumber)); header != nil && header.Hash != log.BlockHash {
+				continue
+			}
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+func (f *Fake) BlockNumber(_ context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.head().Number.Uint64(), nil
+}
+
+func (f *Fake) HeaderByHash(_ context.Context, hash common.Hash) (*types.Header, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	header, ok := f.headers[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return header, nil
+}
+
+func (f *Fake) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if number == nil || number.Sign() < 0 {
+		return f.head(), nil
+	}
+
+	header := f.headerByNumber(number)
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return header, nil
+}
+
+func (f *Fake) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return new(big.Int).Set(f.gasPrice), nil
+}
+
+func (f *Fake) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return new(big.Int).Set(f.gasTipCap), nil
+}
+
+func (f *Fake) FeeHistory(_ context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*types.FeeHistory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.feeHistory != nil {
+		return f.feeHistory, nil
+	}
+
+	if lastBlock == nil || lastBlock.Sign() < 0 {
+		lastBlock = f.head().Number
+	}
+	blockCount = min(blockCount, lastBlock.Uint64()+1)
+
+	feeHistory := &types.FeeHistory{
+		OldestBlock: new(big.Int).Sub(lastBlock, new(big.Int).SetUint64(blockCount-1)),
+	}
+	for i := uint64(0); i < blockCount; i++ {
+		reward := make([]*big.Int, 0, len(rewardPercentiles))
+		for range rewardPercentiles {
+			reward = append(reward, new(big.Int).Set(f.gasTipCap))
+		}
+
+		feeHistory.Reward = append(feeHistory.Reward, reward)
+		feeHistory.BaseFee = append(feeHistory.BaseFee, new(big.Int).Set(f.gasPrice))
+		feeHistory.GasUsedRatio = append(feeHistory.GasUsedRatio, 0.5)
+	}
+	// the base fee of the next block is listed too
+	feeHistory.BaseFee = append(feeHistory.BaseFee, new(big.Int).Set(f.gasPrice))
+
+	return feeHistory, nil
+}
+
+func (f *Fake) EstimateGas(_ context.Context, _ *types.CallMsg) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.gasEstimate, nil
+}
+
+func (f *Fake) PendingNonceAt(_ context.Context, account common.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.nonces[account], nil
+}
+
+// SendTransaction records tx, increments the nonce of its sender and gives it a successful
+// receipt in the head block unless one was set
+func (f *Fake) SendTransaction(_ context.Context, tx *gethtypes.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, tx)
+
+	if sender, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		f.nonces[sender] = max(f.nonces[sender], tx.Nonce()+1)
+	}
+
+	if _, ok := f.receipts[tx.Hash()]; !ok {
+		head := f.head()
+		f.receipts[tx.Hash()] = &types.Receipt{
+			TxHash:            tx.Hash(),
+			Status:            types.ReceiptStatusSuccessful,
+			BlockHash:         head.Hash,
+			BlockNumber:       head.Number,
+			GasUsed:           tx.Gas(),
+			EffectiveGasPrice: tx.GasPrice(),
+		}
+	}
+
+	return nil
+}
+
+func (f *Fake) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	receipt, ok := f.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return receipt, nil
+}
+
+// call answers msg at header, f.mu must be held
+func (f *Fake) call(msg *types.CallMsg, header *types.Header) ([]byte, error) {
+	f.calls = append(f.calls, *msg)
+
+	if isMulticall(msg) {
+		return multicall(msg, header, f.response)
+	}
+
+	return f.response(msg)
+}
+
+// response returns the registered response of msg, f.mu must be held
+func (f *Fake) response(msg *types.CallMsg) ([]byte, error) {
+	var to common.Address
+	if msg.To != nil {
+		to = *msg.To
+	}
+
+	response, ok := f.responses[fakeCallKey{to: to, data: hexutil.Encode(msg.Data)}]
+	if !ok {
+		return nil, fmt.Errorf("%w: to %s, data %s", ErrNoResponse, to.Hex(), hexutil.Encode(msg.Data))
+	}
+
+	return response.data, response.err
+}
+
+// head returns the last canonical header, f.mu must be held
+func (f *Fake) head() *types.Header {
+	return f.canonical[len(f.canonical)-1]
+}
+
+// headerByNumber returns the canonical header at number or nil, f.mu must be held
+func (f *Fake) headerByNumber(number *big.Int) *types.Header {
+	if !number.IsInt64() || number.Int64() >= int64(len(f.canonical)) {
+		return nil
+	}
+
+	return f.canonical[number.Int64()]
+}
+
+func fakeHeaderHash(header *types.Header) common.Hash {
+	return crypto.Keccak256Hash(header.ParentHash.Bytes(), header.Number.Bytes(), new(big.Int).SetUint64(header.Time).Bytes())
+}
+
+func matchLog(log types.Log, query types.FilterQuery) bool {
+	if query.BlockHash != nil {
+		if log.BlockHash != *query.BlockHash {
+			return false
+		}
+	} else {
+		if query.FromBlock != nil && query.FromBlock.Sign() >= 0 && log.BlockNumber < query.FromBlock.Uint64() {
+			return false
+		}
+		if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && log.BlockNumber > query.ToBlock.Uint64() {
+			return false
+		}
+	}
+
+	if len(query.Addresses) > 0 {
+		found := false
+		for _, address := range query.Addresses {
+			if log.Address == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) {
+			return false
+		}
+
+		found := false
+		for _, topic := range topics {
+			if log.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,196 @@
+package adaptertest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+var (
+	target         = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	revertedTarget = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	calldata       = []byte{0xde, 0xad, 0xbe, 0xef}
+)
+
+// aggregate3 calls the Multicall3 of ethClient with calls and returns the results of the calls
+func aggregate3(ctx context.Context, ethClient adapter.EthClientAdapter, calls ...multicall3.Multicall3Call3) ([]multicall3.Multicall3Result, error) {
+	data, err := multicall3.ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ethClient.CallContract(ctx, &types.CallMsg{
+		To:   &adaptertest.MulticallAddress,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := multicall3.ABI.Unpack("aggregate3", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return *abi.ConvertType(outputs[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result), nil
+}
+
+func TestFakeMulticall(t *testing.T) {
+	ctx := context.Background()
+
+	fake := adaptertest.NewFake().
+		OnCall(target, calldata, []byte{0x01}).
+		OnCallError(revertedTarget, calldata, adaptertest.NewRevertError("nope"))
+
+	results, err := aggregate3(ctx, fake,
+		multicall3.Multicall3Call3{Target: target, CallData: calldata},
+		multicall3.Multicall3Call3{Target: revertedTarget, AllowFailure: true, CallData: calldata},
+	)
+	if err != nil {
+		t.Fatalf("aggregate3: %v", err)
+	}
+
+	if len(results) != 2 || !results[0].Success || string(results[0].ReturnData) != "\x01" || results[1].Success {
+		t.Fatalf("results = %+v, want a successful and a failed call", results)
+	}
+	if want := adaptertest.NewRevertError("nope").ErrorData(); hexutil.Encode(results[1].ReturnData) != want {
+		t.Fatalf("revert data = %x, want %s", results[1].ReturnData, want)
+	}
+
+	_, err = aggregate3(ctx, fake, multicall3.Multicall3Call3{Target: revertedTarget, CallData: calldata})
+	var revertErr *adaptertest.RevertError
+	if !errors.As(err, &revertErr) || revertErr.Error() != "execution reverted: Multicall3: call failed" {
+		t.Fatalf("aggregate3 of a required failing call error = %v, want the Multicall3 revert", err)
+	}
+
+	_, err = aggregate3(ctx, fake, multicall3.Multicall3Call3{Target: common.Address{}, AllowFailure: true})
+	if !errors.Is(err, adaptertest.ErrNoResponse) {
+		t.Fatalf("aggregate3 of an unscripted call error = %v, want %v", err, adaptertest.ErrNoResponse)
+	}
+
+	if calls := fake.Calls(); len(calls) != 3 {
+		t.Fatalf("recorded %d calls, want the 3 aggregates", len(calls))
+	}
+}
+
+func TestFakeHeaders(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	fake.Mine()
+	second := fake.Mine()
+	third := fake.Mine()
+
+	// a reorg replaces the headers at and above the new header
+	reorged := fake.AddHeader(&types.Header{ParentHash: second.ParentHash, Number: big.NewInt(2), Time: second.Time + 1})
+
+	head, err := fake.HeaderByNumber(ctx, nil)
+	if err != nil || head.Hash != reorged.Hash {
+		t.Fatalf("head = %+v, %v, want the reorged header", head, err)
+	}
+	if _, err := fake.HeaderByNumber(ctx, big.NewInt(3)); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("header 3 error = %v, want %v", err, ethereum.NotFound)
+	}
+	if header, err := fake.HeaderByHash(ctx, third.Hash); err != nil || header.Hash != third.Hash {
+		t.Fatalf("header of the orphaned hash = %+v, %v, want it to be kept", header, err)
+	}
+	if number, err := fake.BlockNumber(ctx); err != nil || number != 2 {
+		t.Fatalf("block number = %d, %v, want 2", number, err)
+	}
+}
+
+func TestFakeSubscribeNewHead(t *testing.T) {
+	fake := adaptertest.NewFake()
+
+	headers := make(chan *types.Header)
+	sub, err := fake.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		t.Fatalf("SubscribeNewHead: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	mined := make(chan struct{})
+	go func() {
+		defer close(mined)
+		for i := 0; i < 5; i++ {
+			fake.Mine()
+		}
+	}()
+
+	// nobody reads the headers yet, mining must not wait for the subscriber
+	select {
+	case <-mined:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Mine blocked on the subscriber")
+	}
+
+	for want := int64(1); want <= 5; want++ {
+		select {
+		case header := <-headers:
+			if header.Number.Int64() != want {
+				t.Fatalf("header %d received, want %d", header.Number, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("header %d not received", want)
+		}
+	}
+}
+
+func TestFakeFilterLogs(t *testing.T) {
+	fake := adaptertest.NewFake().AddLogs(
+		types.Log{Address: target, BlockNumber: 1},
+		types.Log{Address: target, BlockNumber: 2},
+		types.Log{Address: revertedTarget, BlockNumber: 2},
+		types.Log{Address: target, BlockNumber: 3},
+	)
+
+	logs, err := fake.FilterLogs(context.Background(), types.FilterQuery{
+		FromBlock: big.NewInt(2),
+		ToBlock:   big.NewInt(3),
+		Addresses: []common.Address{target},
+	})
+	if err != nil {
+		t.Fatalf("FilterLogs: %v", err)
+	}
+
+	if len(logs) != 2 || logs[0].BlockNumber != 2 || logs[1].BlockNumber != 3 {
+		t.Fatalf("logs = %+v, want the logs of target in blocks 2 and 3", logs)
+	}
+}
+
+func TestFakeFilterLogsReorged(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	fake.Mine()
+	second := fake.Mine()
+	reorged := fake.AddHeader(&types.Header{ParentHash: second.ParentHash, Number: big.NewInt(2), Time: second.Time + 1})
+	fake.AddLogs(
+		types.Log{Address: target, BlockNumber: 2, BlockHash: second.Hash},
+		types.Log{Address: target, BlockNumber: 2, BlockHash: reorged.Hash},
+	)
+
+	// a block range only returns the logs of the canonical block
+	logs, err := fake.FilterLogs(ctx, types.FilterQuery{FromBlock: big.NewInt(2), ToBlock: big.NewInt(2)})
+	if err != nil || len(logs) != 1 || logs[0].BlockHash != reorged.Hash {
+		t.Fatalf("logs = %+v, %v, want the log of the reorged block", logs, err)
+	}
+
+	// the logs of a replaced block are still returned by its hash
+	logs, err = fake.FilterLogs(ctx, types.FilterQuery{BlockHash: &second.Hash})
+	if err != nil || len(logs) != 1 || logs[0].BlockHash != second.Hash {
+		t.Fatalf("logs = %+v, %v, want the log of the replaced block", logs, err)
+	}
+}
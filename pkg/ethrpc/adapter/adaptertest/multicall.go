@@ -0,0 +1,250 @@
+package adaptertest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+const (
+	revertCallFailed    = "Multicall3: call failed"
+	revertValueMismatch = "Multicall3: value mismatch"
+)
+
+var (
+	// MulticallAddress is the canonical Multicall3 address, calls to it are served by the adapters of this package
+	MulticallAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+	ErrUnsupportedMulticallMethod = errors.New("multicall method is not supported")
+
+	// revertSelector is the selector of Error(string)
+	revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+)
+
+// RevertError is the error of a reverted call, like the error of a node it carries the revert data
+type RevertError struct {
+	reason string
+	data   []byte
+}
+
+// NewRevertError returns the error of a call reverted with Error(reason)
+func NewRevertError(reason string) *RevertError {
+	stringType, _ := abi.NewType("string", "", nil)
+	encoded, _ := abi.Arguments{{Type: stringType}}.Pack(reason)
+
+	return &RevertError{
+		reason: reason,
+		data:   append(append([]byte{}, revertSelector...), encoded...),
+	}
+}
+
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.reason
+}
+
+func (e *RevertError) ErrorCode() int {
+	return 3
+}
+
+func (e *RevertError) ErrorData() interface{} {
+	return hexutil.Encode(e.data)
+}
+
+type (
+	multicallCall struct {
+		Target   common.Address
+		CallData []byte
+	}
+
+	multicallCall3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+
+	multicallCall3Value struct {
+		Target       common.Address
+		AllowFailure bool
+		Value        *big.Int
+		CallData     []byte
+	}
+
+	multicallResult struct {
+		Success    bool
+		ReturnData []byte
+	}
+)
+
+// callFunc executes one call of an aggregate
+type callFunc func(msg *types.CallMsg) ([]byte, error)
+
+func isMulticall(msg *types.CallMsg) bool {
+	return msg.To != nil && *msg.To == MulticallAddress
+}
+
+// multicall serves msg, a call to the Multicall3 of the Fake, by executing its calls one by one at header with call
+func multicall(msg *types.CallMsg, header *types.Header, call callFunc) ([]byte, error) {
+	if len(msg.Data) < 4 {
+		return nil, fmt.Errorf("%w: empty calldata", ErrUnsupportedMulticallMethod)
+	}
+
+	method, err := multicall3.ABI.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMulticallMethod, hexutil.Encode(msg.Data[:4]))
+	}
+
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case "aggregate":
+		calls := *abi.ConvertType(args[0], new([]multicallCall)).(*[]multicallCall)
+		results, err := tryAggregate(msg, true, calls, call)
+		if err != nil {
+			return nil, err
+		}
+
+		returnData := make([][]byte, 0, len(results))
+		for _, result := range results {
+			returnData = append(returnData, result.ReturnData)
+		}
+
+		return method.Outputs.Pack(header.Number, returnData)
+	case "tryAggregate":
+		calls := *abi.ConvertType(args[1], new([]multicallCall)).(*[]multicallCall)
+		results, err := tryAggregate(msg, args[0].(bool), calls, call)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(results)
+	case "blockAndAggregate", "tryBlockAndAggregate":
+		requireSuccess, callsArg := true, args[0]
+		if method.Name == "tryBlockAndAggregate" {
+			requireSuccess, callsArg = args[0].(bool), args[1]
+		}
+
+		calls := *abi.ConvertType(callsArg, new([]multicallCall)).(*[]multicallCall)
+		results, err := tryAggregate(msg, requireSuccess, calls, call)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(header.Number, header.Hash, results)
+	case "aggregate3":
+		calls := *abi.ConvertType(args[0], new([]multicallCall3)).(*[]multicallCall3)
+		results := make([]multicallResult, 0, len(calls))
+		for _, c := range calls {
+			result, err := execute(call, msg.StateOverride, c.Target, nil, c.CallData)
+			if err != nil {
+				return nil, err
+			}
+			if !result.Success && !c.AllowFailure {
+				return nil, NewRevertError(revertCallFailed)
+			}
+			results = append(results, result)
+		}
+
+		return method.Outputs.Pack(results)
+	case "aggregate3Value":
+		calls := *abi.ConvertType(args[0], new([]multicallCall3Value)).(*[]multicallCall3Value)
+		results := make([]multicallResult, 0, len(calls))
+		total := new(big.Int)
+		for _, c := range calls {
+			total.Add(total, c.Value)
+
+			result, err := execute(call, msg.StateOverride, c.Target, c.Value, c.CallData)
+			if err != nil {
+				return nil, err
+			}
+			if !result.Success && !c.AllowFailure {
+				return nil, NewRevertError(revertCallFailed)
+			}
+			results = append(results, result)
+		}
+
+		value := msg.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		if value.Cmp(total) != 0 {
+			return nil, NewRevertError(revertValueMismatch)
+		}
+
+		return method.Outputs.Pack(results)
+	case "getBlockNumber":
+		return method.Outputs.Pack(header.Number)
+	case "getCurrentBlockTimestamp":
+		return method.Outputs.Pack(new(big.Int).SetUint64(header.Time))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMulticallMethod, method.Name)
+	}
+}
+
+func tryAggregate(msg *types.CallMsg, requireSuccess bool, calls []multicallCall, call callFunc) ([]multicallResult, error) {
+	results := make([]multicallResult, 0, len(calls))
+	for _, c := range calls {
+		result, err := execute(call, msg.StateOverride, c.Target, nil, c.CallData)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Success && requireSuccess {
+			return nil, NewRevertError(revertCallFailed)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// execute executes one call from the multicall with its state override, a revert is a failed result and any other error is returned
+func execute(call callFunc, stateOverride types.StateOverride, target common.Address, value *big.Int, data []byte) (multicallResult, error) {
+	returnData, err := call(&types.CallMsg{
+		From:          MulticallAddress,
+		To:            &target,
+		Value:         value,
+		Data:          data,
+		StateOverride: stateOverride,
+	})
+	if err == nil {
+		return multicallResult{Success: true, ReturnData: returnData}, nil
+	}
+
+	revertData, ok := revertDataOf(err)
+	if !ok {
+		return multicallResult{}, err
+	}
+
+	return multicallResult{Success: false, ReturnData: revertData}, nil
+}
+
+// revertDataOf returns the revert data of err, ok is false when err is not a revert
+func revertDataOf(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			revertData, decodeErr := hexutil.Decode(data)
+			if decodeErr == nil {
+				return revertData, true
+			}
+		}
+		return nil, true
+	}
+
+	if strings.Contains(err.Error(), "execution reverted") {
+		return nil, true
+	}
+
+	return nil, false
+}
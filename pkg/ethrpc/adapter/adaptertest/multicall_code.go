@@ -0,0 +1,272 @@
+package adaptertest
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+)
+
+// the aggregates keep their state in memory words, their output starts at multicallOut
+const (
+	regArgs = 0x20 * iota
+	regN
+	regI
+	regRequire
+	regKind
+	regMode
+	regPtr
+	regTotal
+	regHead
+	regTuple
+	regData
+	regAllow
+	regValue
+	regSuccess
+	regLen
+	regBytes
+
+	multicallOut = 0x200
+)
+
+// the kinds of calls of an aggregate, the callData of a call follows its other fields
+const (
+	kindCall = iota
+	kindCall3
+	kindCall3Value
+)
+
+// the outputs of an aggregate, a Result[] or the bytes[] of aggregate
+const (
+	modeResults = iota
+	modeReturnData
+)
+
+// multicallLabels are the jump destinations of the Multicall3 runtime
+type multicallLabels struct {
+	methods      map[string]uint64
+	calls        uint64
+	requireAllow uint64
+	allowed      uint64
+	noValue      uint64
+	noHeader     uint64
+	done         uint64
+	callFailed   uint64
+	valueWrong   uint64
+}
+
+// MulticallCode returns the runtime code of a Multicall3 compatible contract, the canonical bytecode
+// is not bundled. It serves every method of the Multicall3 ABI with the Multicall3 revert reasons,
+// the calls of an aggregate run one after the other in the EVM, so they see each other's state
+// changes and share the gas of the call. The input is not validated like the Solidity decoder does
+func MulticallCode() []byte {
+	// the labels are pushed with a fixed width so a first pass can resolve their positions
+	labels := buildMulticall(multicallLabels{methods: map[string]uint64{}}).labels
+	return buildMulticall(labels).code.Bytes()
+}
+
+type multicallProgram struct {
+	code   *program.Program
+	labels multicallLabels
+}
+
+func buildMulticall(labels multicallLabels) multicallProgram {
+	p := program.New()
+	methods := multicall3.ABI.Methods
+
+	getters := []struct {
+		name string
+		ops  func()
+	}{
+		{"getBasefee", func() { p.Op(vm.BASEFEE) }},
+		{"getBlockHash", func() { p.Push(4).Op(vm.CALLDATALOAD, vm.BLOCKHASH) }},
+		{"getBlockNumber", func() { p.Op(vm.NUMBER) }},
+		{"getChainId", func() { p.Op(vm.CHAINID) }},
+		{"getCurrentBlockCoinbase", func() { p.Op(vm.COINBASE) }},
+		{"getCurrentBlockDifficulty", func() { p.Op(vm.DIFFICULTY) }},
+		{"getCurrentBlockGasLimit", func() { p.Op(vm.GASLIMIT) }},
+		{"getCurrentBlockTimestamp", func() { p.Op(vm.TIMESTAMP) }},
+		{"getEthBalance", func() { p.Push(4).Op(vm.CALLDATALOAD, vm.BALANCE) }},
+		{"getLastBlockHash", func() { p.Push(1).Op(vm.NUMBER, vm.SUB, vm.BLOCKHASH) }},
+	}
+
+	aggregates := []struct {
+		name     string
+		kind     int
+		mode     int
+		tryFirst bool
+		block    bool
+	}{
+		{name: "aggregate", kind: kindCall, mode: modeReturnData},
+		{name: "tryAggregate", kind: kindCall, tryFirst: true},
+		{name: "blockAndAggregate", kind: kindCall, block: true},
+		{name: "tryBlockAndAggregate", kind: kindCall, tryFirst: true, block: true},
+		{name: "aggregate3", kind: kindCall3},
+		{name: "aggregate3Value", kind: kindCall3Value},
+	}
+
+	// selector, the methods are routed in a fixed order so both passes build the same code
+	p.Push(0).Op(vm.CALLDATALOAD).Push(0xe0).Op(vm.SHR)
+	for _, name := range slices.Sorted(maps.Keys(methods)) {
+		p.Op(vm.DUP1).Push(methods[name].ID).Op(vm.EQ)
+		pushLabel(p, labels.methods[name])
+		p.Op(vm.JUMPI)
+	}
+	p.Push(0).Op(vm.DUP1).Op(vm.REVERT)
+
+	for _, getter := range getters {
+		_, labels.methods[getter.name] = p.Jumpdest()
+		getter.ops()
+		p.Push(0).Op(vm.MSTORE)
+		p.Return(0, 32)
+	}
+
+	for _, aggregate := range aggregates {
+		_, labels.methods[aggregate.name] = p.Jumpdest()
+		p.Push(aggregate.kind).Push(regKind).Op(vm.MSTORE)
+		p.Push(aggregate.mode).Push(regMode).Op(vm.MSTORE)
+
+		// the calls follow requireSuccess in the try methods, which the others require
+		if aggregate.tryFirst {
+			p.Push(4).Op(vm.CALLDATALOAD).Push(regRequire).Op(vm.MSTORE)
+			p.Push(0x24).Push(regArgs).Op(vm.MSTORE)
+		} else {
+			p.Push(1).Push(regRequire).Op(vm.MSTORE)
+			p.Push(4).Push(regArgs).Op(vm.MSTORE)
+		}
+
+		// the words before the array: its offset, preceded by the block number and hash if returned
+		head := multicallOut
+		switch {
+		case aggregate.block:
+			p.Op(vm.NUMBER).Push(head).Op(vm.MSTORE)
+			p.Op(vm.NUMBER, vm.BLOCKHASH).Push(head + 0x20).Op(vm.MSTORE)
+			p.Push(0x60).Push(head + 0x40).Op(vm.MSTORE)
+			head += 0x60
+		case aggregate.mode == modeReturnData:
+			p.Op(vm.NUMBER).Push(head).Op(vm.MSTORE)
+			p.Push(0x40).Push(head + 0x20).Op(vm.MSTORE)
+			head += 0x40
+		default:
+			p.Push(0x20).Push(head).Op(vm.MSTORE)
+			head += 0x20
+		}
+		// the array length precedes the heads
+		p.Push(head + 0x20).Push(regHead).Op(vm.MSTORE)
+
+		pushLabel(p, labels.calls)
+		p.Op(vm.JUMP)
+	}
+
+	// the array, its length and the first element after the heads
+	_, labels.calls = p.Jumpdest()
+	p.Push(regArgs).Op(vm.MLOAD, vm.CALLDATALOAD).Push(4).Op(vm.ADD).Push(regArgs).Op(vm.MSTORE)
+	p.Push(regArgs).Op(vm.MLOAD, vm.CALLDATALOAD).Push(regN).Op(vm.MSTORE)
+	p.Push(regN).Op(vm.MLOAD).Push(0x20).Push(regHead).Op(vm.MLOAD, vm.SUB, vm.MSTORE)
+	p.Push(regN).Op(vm.MLOAD).Push(0x20).Op(vm.MUL).Push(regHead).Op(vm.MLOAD, vm.ADD).Push(regPtr).Op(vm.MSTORE)
+
+	_, next := p.Jumpdest()
+	p.Push(regN).Op(vm.MLOAD).Push(regI).Op(vm.MLOAD, vm.EQ)
+	pushLabel(p, labels.done)
+	p.Op(vm.JUMPI)
+
+	// the tuple of call i, its head is relative to the first head
+	p.Push(regI).Op(vm.MLOAD).Push(0x20).Op(vm.MUL).Push(regArgs).Op(vm.MLOAD, vm.ADD).Push(0x20).Op(vm.ADD, vm.CALLDATALOAD)
+	p.Push(regArgs).Op(vm.MLOAD, vm.ADD).Push(0x20).Op(vm.ADD).Push(regTuple).Op(vm.MSTORE)
+
+	// allowFailure of a Call3, or the negated requireSuccess of a Call
+	p.Push(regKind).Op(vm.MLOAD, vm.ISZERO)
+	pushLabel(p, labels.requireAllow)
+	p.Op(vm.JUMPI)
+	p.Push(regTuple).Op(vm.MLOAD).Push(0x20).Op(vm.ADD, vm.CALLDATALOAD).Push(regAllow).Op(vm.MSTORE)
+	pushLabel(p, labels.allowed)
+	p.Op(vm.JUMP)
+	_, labels.requireAllow = p.Jumpdest()
+	p.Push(regRequire).Op(vm.MLOAD, vm.ISZERO).Push(regAllow).Op(vm.MSTORE)
+	_, labels.allowed = p.Jumpdest()
+
+	// the value of a Call3Value, added to the total
+	p.Push(kindCall3Value).Push(regKind).Op(vm.MLOAD, vm.EQ, vm.ISZERO)
+	pushLabel(p, labels.noValue)
+	p.Op(vm.JUMPI)
+	p.Push(regTuple).Op(vm.MLOAD).Push(0x40).Op(vm.ADD, vm.CALLDATALOAD, vm.DUP1).Push(regValue).Op(vm.MSTORE)
+	p.Push(regTotal).Op(vm.MLOAD, vm.ADD).Push(regTotal).Op(vm.MSTORE)
+	_, labels.noValue = p.Jumpdest()
+
+	// the callData is the field after the kind's other fields
+	p.Push(regKind).Op(vm.MLOAD).Push(1).Op(vm.ADD).Push(0x20).Op(vm.MUL).Push(regTuple).Op(vm.MLOAD, vm.ADD, vm.CALLDATALOAD)
+	p.Push(regTuple).Op(vm.MLOAD, vm.ADD).Push(regBytes).Op(vm.MSTORE)
+	p.Push(regBytes).Op(vm.MLOAD, vm.CALLDATALOAD).Push(regLen).Op(vm.MSTORE)
+
+	// a Result starts with success and the offset of returnData, the returnData length follows at data
+	p.Push(regMode).Op(vm.MLOAD, vm.ISZERO).Push(0x40).Op(vm.MUL).Push(regPtr).Op(vm.MLOAD, vm.ADD).Push(regData).Op(vm.MSTORE)
+
+	// the callData is copied where the returnData goes
+	p.Push(regLen).Op(vm.MLOAD).Push(regBytes).Op(vm.MLOAD).Push(0x20).Op(vm.ADD)
+	p.Push(regData).Op(vm.MLOAD).Push(0x20).Op(vm.ADD, vm.CALLDATACOPY)
+
+	p.Push(0).Push(0).Push(regLen).Op(vm.MLOAD)
+	p.Push(regData).Op(vm.MLOAD).Push(0x20).Op(vm.ADD)
+	p.Push(regValue).Op(vm.MLOAD)
+	p.Push(regTuple).Op(vm.MLOAD, vm.CALLDATALOAD)
+	p.Op(vm.GAS, vm.CALL).Push(regSuccess).Op(vm.MSTORE)
+
+	p.Push(regAllow).Op(vm.MLOAD, vm.ISZERO).Push(regSuccess).Op(vm.MLOAD, vm.ISZERO, vm.AND)
+	pushLabel(p, labels.callFailed)
+	p.Op(vm.JUMPI)
+
+	p.Push(regMode).Op(vm.MLOAD)
+	pushLabel(p, labels.noHeader)
+	p.Op(vm.JUMPI)
+	p.Push(regSuccess).Op(vm.MLOAD).Push(regPtr).Op(vm.MLOAD, vm.MSTORE)
+	p.Push(0x40).Push(regPtr).Op(vm.MLOAD).Push(0x20).Op(vm.ADD, vm.MSTORE)
+	_, labels.noHeader = p.Jumpdest()
+
+	// the returnData, padded with zeros
+	p.Op(vm.RETURNDATASIZE).Push(regData).Op(vm.MLOAD, vm.MSTORE)
+	p.Op(vm.RETURNDATASIZE).Push(0).Push(regData).Op(vm.MLOAD).Push(0x20).Op(vm.ADD, vm.RETURNDATACOPY)
+	p.Push(0).Op(vm.RETURNDATASIZE).Push(regData).Op(vm.MLOAD, vm.ADD).Push(0x20).Op(vm.ADD, vm.MSTORE)
+
+	// the head of call i is the offset of its element from the first head
+	p.Push(regHead).Op(vm.MLOAD).Push(regPtr).Op(vm.MLOAD, vm.SUB)
+	p.Push(regI).Op(vm.MLOAD).Push(0x20).Op(vm.MUL).Push(regHead).Op(vm.MLOAD, vm.ADD, vm.MSTORE)
+
+	p.Op(vm.RETURNDATASIZE).Push(31).Op(vm.ADD).Push(0x20).Op(vm.SWAP1, vm.DIV).Push(0x20).Op(vm.MUL)
+	p.Push(regData).Op(vm.MLOAD, vm.ADD).Push(0x20).Op(vm.ADD).Push(regPtr).Op(vm.MSTORE)
+
+	p.Push(regI).Op(vm.MLOAD).Push(1).Op(vm.ADD).Push(regI).Op(vm.MSTORE)
+	p.Jump(next)
+
+	// aggregate3Value requires the value sent to be the total of the calls
+	_, labels.done = p.Jumpdest()
+	p.Push(kindCall3Value).Push(regKind).Op(vm.MLOAD, vm.EQ)
+	p.Push(regTotal).Op(vm.MLOAD, vm.CALLVALUE, vm.EQ, vm.ISZERO, vm.AND)
+	pushLabel(p, labels.valueWrong)
+	p.Op(vm.JUMPI)
+	p.Push(multicallOut).Push(regPtr).Op(vm.MLOAD, vm.SUB).Push(multicallOut).Op(vm.RETURN)
+
+	_, labels.callFailed = p.Jumpdest()
+	revertWith(p, revertCallFailed)
+
+	_, labels.valueWrong = p.Jumpdest()
+	revertWith(p, revertValueMismatch)
+
+	return multicallProgram{code: p, labels: labels}
+}
+
+// revertWith reverts with Error(reason), reason fits in one word
+func revertWith(p *program.Program, reason string) {
+	p.Push(revertSelector).Push(0xe0).Op(vm.SHL).Push(0).Op(vm.MSTORE)
+	p.Push(0x20).Push(4).Op(vm.MSTORE)
+	p.Push(len(reason)).Push(0x24).Op(vm.MSTORE)
+	p.Push(common.RightPadBytes([]byte(reason), 32)).Push(0x44).Op(vm.MSTORE)
+	p.Push(0x64).Push(0).Op(vm.REVERT)
+}
+
+func pushLabel(p *program.Program, label uint64) {
+	p.Append([]byte{byte(vm.PUSH2), byte(label >> 8), byte(label)})
+}
@@ -0,0 +1,159 @@
+package adaptertest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/rpcregistry"
+)
+
+const (
+	// ChainID is the chain id of the simulated backend
+	ChainID int64 = 1337
+
+	ipcFile = "adaptertest.ipc"
+)
+
+// Simulated is an EthClientAdapter on a go-ethereum simulated backend with MulticallCode deployed
+// at MulticallAddress
+type Simulated struct {
+	adapter.EthClientAdapter
+	*simulated.Backend
+
+	ethClient *ethclient.Client
+	heads     *rpcregistry.HeadStream
+	dir       string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewSimulated starts a simulated chain with alloc, options tune the node like simulated.NewBackend options
+func NewSimulated(alloc gethtypes.GenesisAlloc, options ...func(*node.Config, *ethconfig.Config)) (*Simulated, error) {
+	// the IPC endpoint gives access to a plain *ethclient.Client, which the registries return
+	dir, err := os.MkdirTemp("", "adaptertest")
+	if err != nil {
+		return nil, err
+	}
+
+	genesis := make(gethtypes.GenesisAlloc, len(alloc)+1)
+	for address, account := range alloc {
+		genesis[address] = account
+	}
+	if _, ok := genesis[MulticallAddress]; !ok {
+		genesis[MulticallAddress] = gethtypes.Account{Code: MulticallCode(), Balance: new(big.Int)}
+	}
+
+	options = append([]func(*node.Config, *ethconfig.Config){
+		func(nodeConf *node.Config, _ *ethconfig.Config) {
+			nodeConf.IPCPath = filepath.Join(dir, ipcFile)
+		},
+	}, options...)
+	backend := simulated.NewBackend(genesis, options...)
+
+	ethClient, err := ethclient.Dial(filepath.Join(dir, ipcFile))
+	if err != nil {
+		_ = backend.Close()
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to dial simulated backend: %w", err)
+	}
+
+	ethClientAdapter := adapter.NewFromClient(ethClient)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Simulated{
+		EthClientAdapter: ethClientAdapter,
+		Backend:          backend,
+		ethClient:        ethClient,
+		heads:            rpcregistry.NewHeadStream(ChainID, ethClientAdapter),
+		dir:              dir,
+		ctx:              ctx,
+		cancel:           cancel,
+	}, nil
+}
+
+func (s *Simulated) EthClient() *ethclient.Client {
+	return s.ethClient
+}
+
+// NewRpcClient creates an ethrpc client on the adapter with the Multicall3 and the parse middlewares,
+// options are applied after them
+func (s *Simulated) NewRpcClient(options ...func(*ethrpc.Client)) *ethrpc.Client {
+	return ethrpc.NewClient(append([]func(*ethrpc.Client){
+		ethrpc.WithEthClientAdapter(s),
+		ethrpc.WithMulticall(MulticallAddress, multicall3.ABI),
+		ethrpc.WithRequestMiddlewares(ethrpc.ParseRequestMiddleware),
+		ethrpc.WithResponseMiddlewares(ethrpc.ParseResponseMiddleware),
+	}, options...)...)
+}
+
+// Registry returns a registry serving the simulated chain under ChainID
+func (s *Simulated) Registry() *Registry {
+	return &Registry{
+		sim:       s,
+		ethClient: s.ethClient,
+		rpcClient: s.NewRpcClient(),
+	}
+}
+
+// SubscribeNewHeads subscribes to the heads committed on the chain, the subscriptions are closed by Close
+func (s *Simulated) SubscribeNewHeads(bufferSize int) *rpcregistry.HeadSubscription {
+	sub := s.heads.Subscribe(bufferSize)
+	s.heads.Start(s.ctx)
+
+	return sub
+}
+
+func (s *Simulated) Close() error {
+	s.cancel()
+	s.ethClient.Close()
+	err := s.Backend.Close()
+	if removeErr := os.RemoveAll(s.dir); err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
+// Registry is an rpcregistry.IRegistry serving one simulated chain
+type Registry struct {
+	sim       *Simulated
+	ethClient *ethclient.Client
+	rpcClient *ethrpc.Client
+}
+
+func (r *Registry) GetClient(chainID int64) (*ethclient.Client, error) {
+	if chainID != ChainID {
+		return nil, fmt.Errorf("no client found for chainID %d", chainID)
+	}
+
+	return r.ethClient, nil
+}
+
+func (r *Registry) GetRpcClient(chainID int64) (*ethrpc.Client, error) {
+	if chainID != ChainID {
+		return nil, fmt.Errorf("no rpc client found for chainID %d", chainID)
+	}
+
+	return r.rpcClient, nil
+}
+
+func (r *Registry) SubscribeNewHeads(chainID int64, bufferSize int) (*rpcregistry.HeadSubscription, error) {
+	if chainID != ChainID {
+		return nil, fmt.Errorf("%w for chainID %d", rpcregistry.ErrNoWSEndpoint, chainID)
+	}
+
+	return r.sim.SubscribeNewHeads(bufferSize), nil
+}
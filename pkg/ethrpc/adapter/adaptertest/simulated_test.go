@@ -0,0 +1,275 @@
+package adaptertest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+var (
+	// identity is the precompile returning its input
+	identity = common.BytesToAddress([]byte{0x04})
+
+	// revertCode is PUSH1 0 PUSH1 0 REVERT
+	revertCode = common.FromHex("0x60006000fd")
+
+	// counter increments slot 0 and returns it: PUSH1 0 SLOAD PUSH1 1 ADD DUP1 PUSH1 0 SSTORE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	counter     = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	counterCode = common.FromHex("0x6000546001018060005560005260206000f3")
+)
+
+func newSimulated(t *testing.T, alloc gethtypes.GenesisAlloc) (*adaptertest.Simulated, context.Context) {
+	t.Helper()
+
+	sim, err := adaptertest.NewSimulated(alloc)
+	if err != nil {
+		t.Fatalf("NewSimulated: %v", err)
+	}
+	t.Cleanup(func() { _ = sim.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+
+	return sim, ctx
+}
+
+// callMulticall calls method of the Multicall3 of sim with value and returns its outputs
+func callMulticall(ctx context.Context, sim *adaptertest.Simulated, value *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := multicall3.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := sim.CallContract(ctx, &types.CallMsg{
+		From:  target,
+		To:    &adaptertest.MulticallAddress,
+		Value: value,
+		Data:  data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return multicall3.ABI.Unpack(method, response)
+}
+
+// assertReverted checks that err is a revert of the node with Error(reason)
+func assertReverted(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) || dataErr.ErrorData() != adaptertest.NewRevertError(reason).ErrorData() {
+		t.Fatalf("error = %v, want a revert with %q", err, reason)
+	}
+}
+
+func TestSimulatedMulticall(t *testing.T) {
+	sim, ctx := newSimulated(t, gethtypes.GenesisAlloc{
+		revertedTarget: {Code: revertCode, Balance: new(big.Int)},
+		counter:        {Code: counterCode, Balance: new(big.Int)},
+	})
+
+	results, err := aggregate3(ctx, sim,
+		multicall3.Multicall3Call3{Target: identity, CallData: calldata},
+		multicall3.Multicall3Call3{Target: revertedTarget, AllowFailure: true},
+	)
+	if err != nil {
+		t.Fatalf("aggregate3: %v", err)
+	}
+
+	if len(results) != 2 || !results[0].Success || string(results[0].ReturnData) != string(calldata) || results[1].Success {
+		t.Fatalf("results = %+v, want the echoed calldata and a failed call", results)
+	}
+
+	_, err = aggregate3(ctx, sim, multicall3.Multicall3Call3{Target: revertedTarget})
+	assertReverted(t, err, "Multicall3: call failed")
+
+	// the calls run in the EVM one after the other and see each other's state changes
+	results, err = aggregate3(ctx, sim,
+		multicall3.Multicall3Call3{Target: counter},
+		multicall3.Multicall3Call3{Target: counter},
+	)
+	if err != nil {
+		t.Fatalf("aggregate3 of the counter: %v", err)
+	}
+	if len(results) != 2 || new(big.Int).SetBytes(results[0].ReturnData).Int64() != 1 || new(big.Int).SetBytes(results[1].ReturnData).Int64() != 2 {
+		t.Fatalf("counter results = %+v, want 1 then 2", results)
+	}
+}
+
+func TestSimulatedMulticallMethods(t *testing.T) {
+	sim, ctx := newSimulated(t, gethtypes.GenesisAlloc{
+		revertedTarget: {Code: revertCode, Balance: new(big.Int)},
+		target:         {Balance: big.NewInt(1e18)},
+	})
+	sim.Commit()
+
+	head, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatalf("HeaderByNumber: %v", err)
+	}
+
+	calls := []multicall3.Multicall3Call{
+		{Target: identity, CallData: calldata},
+		{Target: identity},
+	}
+
+	outputs, err := callMulticall(ctx, sim, nil, "aggregate", calls)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	returnData := outputs[1].([][]byte)
+	if outputs[0].(*big.Int).Cmp(head.Number) != 0 || len(returnData) != 2 || string(returnData[0]) != string(calldata) || len(returnData[1]) != 0 {
+		t.Fatalf("aggregate = %v, want the block number and the echoed calldata", outputs)
+	}
+
+	outputs, err = callMulticall(ctx, sim, nil, "tryBlockAndAggregate", false, append(calls, multicall3.Multicall3Call{Target: revertedTarget}))
+	if err != nil {
+		t.Fatalf("tryBlockAndAggregate: %v", err)
+	}
+	results := *abi.ConvertType(outputs[2], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if outputs[0].(*big.Int).Cmp(head.Number) != 0 || len(results) != 3 || !results[0].Success || results[2].Success {
+		t.Fatalf("tryBlockAndAggregate = %v, want the block number and a failed last call", outputs)
+	}
+
+	_, err = callMulticall(ctx, sim, nil, "tryAggregate", true, []multicall3.Multicall3Call{{Target: revertedTarget}})
+	assertReverted(t, err, "Multicall3: call failed")
+
+	valueCalls := []multicall3.Multicall3Call3Value{{Target: identity, Value: big.NewInt(7), CallData: calldata}}
+	outputs, err = callMulticall(ctx, sim, big.NewInt(7), "aggregate3Value", valueCalls)
+	if err != nil {
+		t.Fatalf("aggregate3Value: %v", err)
+	}
+	results = *abi.ConvertType(outputs[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if len(results) != 1 || !results[0].Success || string(results[0].ReturnData) != string(calldata) {
+		t.Fatalf("aggregate3Value = %+v, want the echoed calldata", results)
+	}
+
+	_, err = callMulticall(ctx, sim, big.NewInt(8), "aggregate3Value", valueCalls)
+	assertReverted(t, err, "Multicall3: value mismatch")
+
+	getters := []struct {
+		method string
+		args   []interface{}
+		want   *big.Int
+	}{
+		{"getBlockNumber", nil, head.Number},
+		{"getCurrentBlockTimestamp", nil, new(big.Int).SetUint64(head.Time)},
+		{"getChainId", nil, big.NewInt(adaptertest.ChainID)},
+		{"getEthBalance", []interface{}{target}, big.NewInt(1e18)},
+	}
+	for _, getter := range getters {
+		outputs, err := callMulticall(ctx, sim, nil, getter.method, getter.args...)
+		if err != nil || outputs[0].(*big.Int).Cmp(getter.want) != 0 {
+			t.Fatalf("%s = %v, %v, want %s", getter.method, outputs, err, getter.want)
+		}
+	}
+
+	outputs, err = callMulticall(ctx, sim, nil, "getLastBlockHash")
+	if err != nil || common.Hash(outputs[0].([32]byte)) != head.ParentHash {
+		t.Fatalf("getLastBlockHash = %v, %v, want %s", outputs, err, head.ParentHash)
+	}
+}
+
+func TestSimulatedTransact(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	sim, ctx := newSimulated(t, gethtypes.GenesisAlloc{
+		from: {Balance: big.NewInt(1e18)},
+	})
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(adaptertest.ChainID))
+	if err != nil {
+		t.Fatalf("NewKeyedTransactorWithChainID: %v", err)
+	}
+	opts.Value = big.NewInt(1000)
+
+	tx, err := adapter.Transact(ctx, sim, opts, target, nil)
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := adapter.WaitForReceipt(ctx, sim, tx.Hash(), 10*time.Millisecond)
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("WaitForReceipt = %+v, %v, want a successful receipt", receipt, err)
+	}
+
+	head, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil || head.Number.Cmp(receipt.BlockNumber) != 0 || head.Hash != receipt.BlockHash {
+		t.Fatalf("head = %+v, %v, want the block of the receipt", head, err)
+	}
+
+	balance, err := sim.EthClient().BalanceAt(ctx, target, nil)
+	if err != nil || balance.Int64() != 1000 {
+		t.Fatalf("balance of target = %s, %v, want 1000", balance, err)
+	}
+}
+
+func TestSimulatedRegistry(t *testing.T) {
+	sim, ctx := newSimulated(t, nil)
+	registry := sim.Registry()
+
+	client, err := registry.GetClient(adaptertest.ChainID)
+	if err != nil {
+		t.Fatalf("GetClient: %v", err)
+	}
+	if chainID, err := client.ChainID(ctx); err != nil || chainID.Int64() != adaptertest.ChainID {
+		t.Fatalf("chain id = %s, %v, want %d", chainID, err, adaptertest.ChainID)
+	}
+
+	if _, err := registry.GetRpcClient(adaptertest.ChainID + 1); err == nil {
+		t.Fatal("GetRpcClient of another chain succeeded")
+	}
+
+	sub, err := registry.SubscribeNewHeads(adaptertest.ChainID, 0)
+	if err != nil {
+		t.Fatalf("SubscribeNewHeads: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// blocks committed before the stream subscribed to the node are not sent
+	timeout := time.After(10 * time.Second)
+	for received := false; !received; {
+		sim.Commit()
+		select {
+		case header := <-sub.Headers():
+			received = header.Number.Sign() > 0
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("no head received")
+		}
+	}
+	if err := sim.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// the subscription is closed with the chain, possibly after heads still buffered
+	timeout = time.After(10 * time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-sub.Headers():
+			closed = !ok
+		case <-timeout:
+			t.Fatal("subscription not closed by Close")
+		}
+	}
+}
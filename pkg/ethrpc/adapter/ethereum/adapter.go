@@ -6,8 +6,10 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	adaptertypes "github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
 )
@@ -22,23 +24,47 @@ func NewAdapter(url string) (*Adapter, error) {
 		return nil, err
 	}
 
+	return NewAdapterFromClient(client), nil
+}
+
+// NewAdapterFromClient creates an adapter sharing an already dialed client
+func NewAdapterFromClient(client *ethclient.Client) *Adapter {
 	return &Adapter{
 		client: client,
-	}, nil
+	}
 }
 
 func (a *Adapter) CallContract(ctx context.Context, msg *adaptertypes.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if len(msg.StateOverride) > 0 {
+		return a.callWithStateOverride(ctx, msg, adaptertypes.ToBlockNumArg(blockNumber))
+	}
+
 	ethereumCallMsg := a.convertToEthereumCallMsg(msg)
 
 	return a.client.CallContract(ctx, ethereumCallMsg, blockNumber)
 }
 
 func (a *Adapter) CallContractAtHash(ctx context.Context, msg *adaptertypes.CallMsg, blockHash common.Hash) ([]byte, error) {
+	if len(msg.StateOverride) > 0 {
+		return a.callWithStateOverride(ctx, msg, rpc.BlockNumberOrHashWithHash(blockHash, false))
+	}
+
 	ethereumCallMsg := a.convertToEthereumCallMsg(msg)
 
 	return a.client.CallContractAtHash(ctx, ethereumCallMsg, blockHash)
 }
 
+// callWithStateOverride sends the eth_call itself, ethclient has no state override parameter
+func (a *Adapter) callWithStateOverride(ctx context.Context, msg *adaptertypes.CallMsg, block interface{}) ([]byte, error) {
+	var result hexutil.Bytes
+	err := a.client.Client().CallContext(ctx, &result, "eth_call", adaptertypes.ToCallArg(msg), block, msg.StateOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (a *Adapter) SubscribeNewHead(ctx context.Context, headerChannel chan<- *adaptertypes.Header) (adaptertypes.Subscription, error) {
 	originHeaderChannel := make(chan *types.Header)
 	sub, err := a.client.SubscribeNewHead(ctx, originHeaderChannel)
@@ -46,15 +72,18 @@ func (a *Adapter) SubscribeNewHead(ctx context.Context, headerChannel chan<- *ad
 		return nil, err
 	}
 
+	// originHeaderChannel is not closed, the subscription may still send to it until it is unsubscribed
 	go func() {
-		defer close(originHeaderChannel)
-
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case originHeader := <-originHeaderChannel:
-				headerChannel <- a.convertFromEthereumHeader(originHeader)
+				select {
+				case <-ctx.Done():
+					return
+				case headerChannel <- a.convertFromEthereumHeader(originHeader):
+				}
 			}
 		}
 	}()
@@ -97,6 +126,76 @@ func (a *Adapter) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return a.client.SuggestGasPrice(ctx)
 }
 
+func (a *Adapter) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return a.client.SuggestGasTipCap(ctx)
+}
+
+func (a *Adapter) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*adaptertypes.FeeHistory, error) {
+	feeHistory, err := a.client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adaptertypes.FeeHistory{
+		OldestBlock:  feeHistory.OldestBlock,
+		Reward:       feeHistory.Reward,
+		BaseFee:      feeHistory.BaseFee,
+		GasUsedRatio: feeHistory.GasUsedRatio,
+	}, nil
+}
+
+func (a *Adapter) EstimateGas(ctx context.Context, msg *adaptertypes.CallMsg) (uint64, error) {
+	return a.client.EstimateGas(ctx, a.convertToEthereumCallMsg(msg))
+}
+
+func (a *Adapter) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return a.client.PendingNonceAt(ctx, account)
+}
+
+func (a *Adapter) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return a.client.SendTransaction(ctx, tx)
+}
+
+func (a *Adapter) TransactionReceipt(ctx context.Context, txHash common.Hash) (*adaptertypes.Receipt, error) {
+	receipt, err := a.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adaptertypes.Receipt{
+		TxHash:            receipt.TxHash,
+		Status:            receipt.Status,
+		BlockHash:         receipt.BlockHash,
+		BlockNumber:       receipt.BlockNumber,
+		TxIndex:           receipt.TransactionIndex,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		ContractAddress:   receipt.ContractAddress,
+		Logs:              a.convertFromEthereumLogPointers(receipt.Logs),
+	}, nil
+}
+
+func (a *Adapter) BatchCall(ctx context.Context, elems []adaptertypes.BatchElem) error {
+	batch := make([]rpc.BatchElem, 0, len(elems))
+	for _, elem := range elems {
+		batch = append(batch, rpc.BatchElem{
+			Method: elem.Method,
+			Args:   elem.Args,
+			Result: elem.Result,
+		})
+	}
+
+	if err := a.client.Client().BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+
+	for i := range batch {
+		elems[i].Error = batch[i].Error
+	}
+
+	return nil
+}
+
 func (a *Adapter) convertToEthereumCallMsg(originMsg *adaptertypes.CallMsg) ethereum.CallMsg {
 	return ethereum.CallMsg{
 		From:       originMsg.From,
@@ -143,6 +242,15 @@ func (_ *Adapter) convertFromEthereumHeader(originHeader *types.Header) *adapter
 	}
 }
 
+func (a *Adapter) convertFromEthereumLogPointers(originLogs []*types.Log) []adaptertypes.Log {
+	logs := make([]types.Log, 0, len(originLogs))
+	for _, originLog := range originLogs {
+		logs = append(logs, *originLog)
+	}
+
+	return a.convertFromEthereumLogs(logs)
+}
+
 func (_ *Adapter) convertFromEthereumLogs(originLogs []types.Log) []adaptertypes.Log {
 	logs := make([]adaptertypes.Log, 0, len(originLogs))
 	for i := range originLogs {
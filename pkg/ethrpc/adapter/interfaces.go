@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 type EthClientAdapter interface {
@@ -20,4 +21,20 @@ type EthClientAdapter interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*types.FeeHistory, error)
+	EstimateGas(ctx context.Context, msg *types.CallMsg) (uint64, error)
+
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	// SendTransaction sends a signed transaction. It takes the geth transaction rather than an adapter type
+	// because bind.TransactOpts signers and the RLP encoding sent on the wire are defined on it, see Transact
+	SendTransaction(ctx context.Context, tx *gethtypes.Transaction) error
+	// TransactionReceipt returns ethereum.NotFound while the transaction is pending
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// BatchCaller is implemented by adapters able to send several JSON-RPC requests in one batch
+type BatchCaller interface {
+	// BatchCall sends all elems in one batch, the error is only set when the batch itself fails
+	BatchCall(ctx context.Context, elems []types.BatchElem) error
 }
@@ -0,0 +1,53 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+const DefaultReceiptPollInterval = 500 * time.Millisecond
+
+var (
+	ErrTransactionFailed = errors.New("transaction failed")
+)
+
+// WaitForReceipt polls the receipt of txHash every interval until it is mined or ctx is done,
+// ErrTransactionFailed is returned together with the receipt of a reverted transaction
+func WaitForReceipt(ctx context.Context, ethClient EthClientAdapter, txHash common.Hash, interval time.Duration) (*types.Receipt, error) {
+	if interval <= 0 {
+		interval = DefaultReceiptPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		receipt, err := ethClient.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil && receipt.Status != types.ReceiptStatusSuccessful:
+			return receipt, fmt.Errorf("%w: %s with status %d", ErrTransactionFailed, txHash.Hex(), receipt.Status)
+		case err == nil:
+			return receipt, nil
+		case !errors.Is(err, ethereum.NotFound):
+			// a failing node must not end the wait, the error is reported if the wait times out
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("waiting for transaction %s: %w, last error: %w", txHash.Hex(), ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("waiting for transaction %s: %w", txHash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+var (
+	ErrNoSigner = errors.New("no signer set for the transaction")
+)
+
+// SignerFn signs a transaction, bounding any remote signing call with ctx
+type SignerFn func(ctx context.Context, tx *gethtypes.Transaction) (*gethtypes.Transaction, error)
+
+// Transact signs a transaction calling to with data with opts.Signer and sends it through ethClient,
+// see TransactWithSigner
+func Transact(ctx context.Context, ethClient EthClientAdapter, opts *bind.TransactOpts, to common.Address, data []byte) (*gethtypes.Transaction, error) {
+	if opts.Signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	return TransactWithSigner(ctx, ethClient, opts, func(_ context.Context, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+		return opts.Signer(opts.From, tx)
+	}, to, data)
+}
+
+// TransactWithSigner signs a transaction calling to with data with sign and sends it through
+// ethClient, opts.Signer is ignored. The nonce, gas limit and fees not set on opts are filled like
+// bind does: the pending nonce, the estimated gas and a fee cap of the suggested tip plus twice the
+// next base fee, or the suggested gas price on chains without base fee. A transaction with
+// opts.NoSend is signed but not sent
+func TransactWithSigner(
+	ctx context.Context,
+	ethClient EthClientAdapter,
+	opts *bind.TransactOpts,
+	sign SignerFn,
+	to common.Address,
+	data []byte,
+) (*gethtypes.Transaction, error) {
+	if sign == nil {
+		return nil, ErrNoSigner
+	}
+
+	value := opts.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = opts.Nonce.Uint64()
+	} else {
+		var err error
+		nonce, err = ethClient.PendingNonceAt(ctx, opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve account nonce: %w", err)
+		}
+	}
+
+	msg := &types.CallMsg{
+		From:  opts.From,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	}
+
+	baseFee, err := nextBaseFee(ctx, ethClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.GasPrice != nil || baseFee == nil {
+		msg.GasPrice = opts.GasPrice
+		if msg.GasPrice == nil {
+			msg.GasPrice, err = ethClient.SuggestGasPrice(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to suggest gas price: %w", err)
+			}
+		}
+	} else {
+		msg.GasTipCap = opts.GasTipCap
+		if msg.GasTipCap == nil {
+			msg.GasTipCap, err = ethClient.SuggestGasTipCap(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+			}
+		}
+
+		msg.GasFeeCap = opts.GasFeeCap
+		if msg.GasFeeCap == nil {
+			msg.GasFeeCap = new(big.Int).Add(msg.GasTipCap, new(big.Int).Mul(baseFee, big.NewInt(2)))
+		}
+
+		if msg.GasFeeCap.Cmp(msg.GasTipCap) < 0 {
+			return nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", msg.GasFeeCap, msg.GasTipCap)
+		}
+	}
+
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		gasLimit, err = ethClient.EstimateGas(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas needed: %w", err)
+		}
+	}
+
+	var txData gethtypes.TxData
+	if msg.GasPrice != nil {
+		txData = &gethtypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: msg.GasPrice,
+			Gas:      gasLimit,
+			To:       &to,
+			Value:    value,
+			Data:     data,
+		}
+	} else {
+		txData = &gethtypes.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: msg.GasTipCap,
+			GasFeeCap: msg.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     value,
+			Data:      data,
+		}
+	}
+
+	tx, err := sign(ctx, gethtypes.NewTx(txData))
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.NoSend {
+		return tx, nil
+	}
+
+	if err := ethClient.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// nextBaseFee returns the base fee of the next block, nil when the chain has no base fee
+func nextBaseFee(ctx context.Context, ethClient EthClientAdapter) (*big.Int, error) {
+	feeHistory, err := ethClient.FeeHistory(ctx, 1, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the base fee: %w", err)
+	}
+
+	// the base fees include the one of the block after the newest block
+	if len(feeHistory.BaseFee) == 0 {
+		return nil, nil
+	}
+
+	baseFee := feeHistory.BaseFee[len(feeHistory.BaseFee)-1]
+	if baseFee == nil || baseFee.Sign() == 0 {
+		return nil, nil
+	}
+
+	return baseFee, nil
+}
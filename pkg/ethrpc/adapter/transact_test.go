@@ -0,0 +1,157 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+var target = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func newTransactor(t *testing.T) *bind.TransactOpts {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("NewKeyedTransactorWithChainID: %v", err)
+	}
+
+	return opts
+}
+
+func TestTransact(t *testing.T) {
+	ctx := context.Background()
+	opts := newTransactor(t)
+
+	fake := adaptertest.NewFake().
+		SetNonce(opts.From, 7).
+		SetGasPrice(big.NewInt(100)).
+		SetGasTipCap(big.NewInt(2)).
+		SetGasEstimate(50_000)
+
+	tx, err := adapter.Transact(ctx, fake, opts, target, []byte{0x01})
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+
+	if tx.Type() != gethtypes.DynamicFeeTxType || tx.Nonce() != 7 || tx.Gas() != 50_000 || *tx.To() != target {
+		t.Fatalf("tx = type %d, nonce %d, gas %d, to %s", tx.Type(), tx.Nonce(), tx.Gas(), tx.To())
+	}
+	if tx.GasTipCap().Int64() != 2 || tx.GasFeeCap().Int64() != 202 {
+		t.Fatalf("fees = tip %s, cap %s, want 2 and 202", tx.GasTipCap(), tx.GasFeeCap())
+	}
+	if sent := fake.SentTransactions(); len(sent) != 1 || sent[0].Hash() != tx.Hash() {
+		t.Fatalf("sent %d transactions, want the signed one", len(sent))
+	}
+
+	receipt, err := adapter.WaitForReceipt(ctx, fake, tx.Hash(), 0)
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("WaitForReceipt = %+v, %v, want a successful receipt", receipt, err)
+	}
+
+	// the pending nonce moved past the sent transaction
+	next, err := adapter.Transact(ctx, fake, opts, target, nil)
+	if err != nil || next.Nonce() != 8 {
+		t.Fatalf("next Transact = %v, %v, want nonce 8", next, err)
+	}
+}
+
+func TestTransactWithoutBaseFee(t *testing.T) {
+	opts := newTransactor(t)
+
+	fake := adaptertest.NewFake().
+		SetGasPrice(big.NewInt(100)).
+		SetFeeHistory(&types.FeeHistory{BaseFee: []*big.Int{big.NewInt(0), big.NewInt(0)}})
+
+	tx, err := adapter.Transact(context.Background(), fake, opts, target, nil)
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+
+	if tx.Type() != gethtypes.LegacyTxType || tx.GasPrice().Int64() != 100 {
+		t.Fatalf("tx = type %d, gas price %s, want a legacy transaction at 100", tx.Type(), tx.GasPrice())
+	}
+}
+
+func TestTransactOptions(t *testing.T) {
+	opts := newTransactor(t)
+	opts.Nonce = big.NewInt(3)
+	opts.GasLimit = 21_000
+	opts.GasPrice = big.NewInt(5)
+	opts.NoSend = true
+
+	fake := adaptertest.NewFake()
+
+	tx, err := adapter.Transact(context.Background(), fake, opts, target, nil)
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+
+	if tx.Type() != gethtypes.LegacyTxType || tx.Nonce() != 3 || tx.Gas() != 21_000 || tx.GasPrice().Int64() != 5 {
+		t.Fatalf("tx = type %d, nonce %d, gas %d, gas price %s", tx.Type(), tx.Nonce(), tx.Gas(), tx.GasPrice())
+	}
+	if len(fake.SentTransactions()) != 0 {
+		t.Fatal("a NoSend transaction was sent")
+	}
+}
+
+func TestTransactWithSigner(t *testing.T) {
+	opts := newTransactor(t)
+	signer := opts.Signer
+	opts.Signer = nil
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "execute")
+
+	var signCtx context.Context
+	sign := func(ctx context.Context, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+		signCtx = ctx
+		return signer(opts.From, tx)
+	}
+
+	fake := adaptertest.NewFake()
+
+	tx, err := adapter.TransactWithSigner(ctx, fake, opts, sign, target, nil)
+	if err != nil {
+		t.Fatalf("TransactWithSigner: %v", err)
+	}
+
+	if signCtx == nil || signCtx.Value(ctxKey{}) != "execute" {
+		t.Fatal("the signer did not get the context of the call")
+	}
+	if sent := fake.SentTransactions(); len(sent) != 1 || sent[0].Hash() != tx.Hash() {
+		t.Fatalf("sent %d transactions, want the signed one", len(sent))
+	}
+
+	if _, err := adapter.TransactWithSigner(ctx, fake, opts, nil, target, nil); !errors.Is(err, adapter.ErrNoSigner) {
+		t.Fatalf("TransactWithSigner without signer error = %v, want %v", err, adapter.ErrNoSigner)
+	}
+}
+
+func TestWaitForReceiptReverted(t *testing.T) {
+	txHash := common.HexToHash("0x01")
+	fake := adaptertest.NewFake().SetReceipt(txHash, &types.Receipt{
+		TxHash: txHash,
+		Status: types.ReceiptStatusFailed,
+	})
+
+	receipt, err := adapter.WaitForReceipt(context.Background(), fake, txHash, 0)
+	if !errors.Is(err, adapter.ErrTransactionFailed) || receipt == nil {
+		t.Fatalf("WaitForReceipt = %+v, %v, want the receipt and %v", receipt, err, adapter.ErrTransactionFailed)
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ToCallArg encodes msg as the call object of eth_call like ethclient does, the state override is
+// a separate parameter of eth_call
+func ToCallArg(msg *CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+
+	return arg
+}
+
+// ToBlockNumArg encodes blockNumber as the block parameter of a request, nil means the latest block
+func ToBlockNumArg(blockNumber *big.Int) string {
+	if blockNumber == nil {
+		return "latest"
+	}
+
+	if blockNumber.Sign() >= 0 {
+		return hexutil.EncodeBig(blockNumber)
+	}
+
+	// negative numbers are the special block tags, e.g. pending or finalized
+	return rpc.BlockNumber(blockNumber.Int64()).String()
+}
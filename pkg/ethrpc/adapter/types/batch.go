@@ -0,0 +1,13 @@
+package types
+
+// BatchElem is one request of a JSON-RPC batch
+type BatchElem struct {
+	Method string
+	Args   []interface{}
+
+	// Result is the pointer the response result is decoded into
+	Result interface{}
+
+	// Error is set when the server returns an error for this request or the result can not be decoded
+	Error error
+}
@@ -18,6 +18,8 @@ type CallMsg struct {
 	Data      []byte          // input data, usually an ABI-encoded contract method invocation
 
 	AccessList AccessList // EIP-2930 access list.
+
+	StateOverride StateOverride // state replaced during the call, only used by calls
 }
 
 // AccessList is an EIP-2930 access list.
@@ -25,6 +27,6 @@ type AccessList []AccessTuple
 
 // AccessTuple is the element type of an access list.
 type AccessTuple struct {
-	Address     common.Address
-	StorageKeys []common.Hash
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
 }
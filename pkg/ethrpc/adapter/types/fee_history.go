@@ -0,0 +1,13 @@
+package types
+
+import (
+	"math/big"
+)
+
+// FeeHistory is the result of eth_feeHistory, the slices are ordered from the oldest block
+type FeeHistory struct {
+	OldestBlock  *big.Int
+	Reward       [][]*big.Int
+	BaseFee      []*big.Int
+	GasUsedRatio []float64
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ReceiptStatusFailed     = uint64(0)
+	ReceiptStatusSuccessful = uint64(1)
+)
+
+type Receipt struct {
+	TxHash            common.Hash    `json:"transactionHash"`
+	Status            uint64         `json:"status"`
+	BlockHash         common.Hash    `json:"blockHash"`
+	BlockNumber       *big.Int       `json:"blockNumber"`
+	TxIndex           uint           `json:"transactionIndex"`
+	GasUsed           uint64         `json:"gasUsed"`
+	EffectiveGasPrice *big.Int       `json:"effectiveGasPrice"`
+	ContractAddress   common.Address `json:"contractAddress"`
+	Logs              []Log          `json:"logs"`
+}
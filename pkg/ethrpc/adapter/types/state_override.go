@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StateOverride replaces the state of accounts during a call, it is the state override set of eth_call
+type StateOverride map[common.Address]OverrideAccount
+
+// OverrideAccount is the overridden state of one account, nil fields are left untouched
+type OverrideAccount struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+
+	// State replaces the whole storage, an empty map wipes it
+	State map[common.Hash]common.Hash
+	// StateDiff replaces single storage slots
+	StateDiff map[common.Hash]common.Hash
+}
+
+func (a OverrideAccount) MarshalJSON() ([]byte, error) {
+	type account struct {
+		Nonce   *hexutil.Uint64 `json:"nonce,omitempty"`
+		Code    *hexutil.Bytes  `json:"code,omitempty"`
+		Balance *hexutil.Big    `json:"balance,omitempty"`
+		// State is an interface so an empty map is kept by omitempty
+		State     interface{}                 `json:"state,omitempty"`
+		StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+	}
+
+	output := account{
+		Nonce:     (*hexutil.Uint64)(a.Nonce),
+		Balance:   (*hexutil.Big)(a.Balance),
+		StateDiff: a.StateDiff,
+	}
+	if a.Code != nil {
+		code := hexutil.Bytes(a.Code)
+		output.Code = &code
+	}
+	if a.State != nil {
+		output.State = a.State
+	}
+
+	return json.Marshal(output)
+}
@@ -0,0 +1,294 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+const (
+	DefaultBatchMaxSize     = 100
+	DefaultBatchFlushWindow = 10 * time.Millisecond
+	DefaultBatchTimeout     = 30 * time.Second
+)
+
+var (
+	ErrBatchNotSupported = fmt.Errorf("eth client adapter does not support batch calls")
+)
+
+// Batcher combines independent requests into JSON-RPC batches. Requests are queued until
+// the batch reaches its max size or the flush window since the first queued request expires,
+// every caller blocks until the batch holding its request has been sent
+type Batcher struct {
+	client      *Client
+	batchCaller adapter.BatchCaller
+
+	maxSize     int
+	flushWindow time.Duration
+	timeout     time.Duration
+
+	mu      sync.Mutex
+	pending []*batchItem
+	timer   *time.Timer
+}
+
+type batchItem struct {
+	elem types.BatchElem
+	done chan struct{}
+
+	// cancelled is set when the caller stopped waiting, the response is then not decoded into elem.Result
+	mu        sync.Mutex
+	cancelled bool
+}
+
+func WithBatchMaxSize(maxSize int) func(*Batcher) {
+	return func(batcher *Batcher) {
+		batcher.maxSize = maxSize
+	}
+}
+
+func WithBatchFlushWindow(flushWindow time.Duration) func(*Batcher) {
+	return func(batcher *Batcher) {
+		batcher.flushWindow = flushWindow
+	}
+}
+
+// WithBatchTimeout sets the timeout of sending one batch
+func WithBatchTimeout(timeout time.Duration) func(*Batcher) {
+	return func(batcher *Batcher) {
+		batcher.timeout = timeout
+	}
+}
+
+// NewBatcher creates a batcher sending through the client's adapter, which must implement adapter.BatchCaller
+func (a *Client) NewBatcher(options ...func(*Batcher)) (*Batcher, error) {
+	batchCaller, ok := a.adapter.(adapter.BatchCaller)
+	if !ok {
+		return nil, ErrBatchNotSupported
+	}
+
+	batcher := &Batcher{
+		client:      a,
+		batchCaller: batchCaller,
+		maxSize:     DefaultBatchMaxSize,
+		flushWindow: DefaultBatchFlushWindow,
+		timeout:     DefaultBatchTimeout,
+	}
+
+	for _, o := range options {
+		o(batcher)
+	}
+
+	return batcher, nil
+}
+
+// NewRequest creates a request executed through the batcher
+func (b *Batcher) NewRequest() *Request {
+	return &Request{
+		executor: b,
+	}
+}
+
+// Execute runs the client's middlewares around an eth_call sent in the next batch
+func (b *Batcher) Execute(req *Request) (*Response, error) {
+	return b.client.executeWith(b, req, b.callContract)
+}
+
+func (b *Batcher) callContract(req *Request) ([]byte, error) {
+	args := []interface{}{types.ToCallArg(req.RawCallMsg), toBlockArg(req.BlockNumber, req.BlockHash)}
+	if len(req.RawCallMsg.StateOverride) > 0 {
+		args = append(args, req.RawCallMsg.StateOverride)
+	}
+
+	var rawResponse hexutil.Bytes
+	err := b.do(req.Context(), "eth_call", &rawResponse, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawResponse, nil
+}
+
+func (b *Batcher) GetMulticallContractAddress() common.Address {
+	return b.client.GetMulticallContractAddress()
+}
+
+func (b *Batcher) GetMulticallABI() *abi.ABI {
+	return b.client.GetMulticallABI()
+}
+
+// BalanceAt returns the wei balance of account at blockNumber, nil means the latest block
+func (b *Batcher) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	if err := b.do(ctx, "eth_getBalance", &result, account, toBlockArg(blockNumber, zeroHash)); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&result), nil
+}
+
+// CodeAt returns the contract code of account at blockNumber, nil means the latest block
+func (b *Batcher) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := b.do(ctx, "eth_getCode", &result, account, toBlockArg(blockNumber, zeroHash)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// HeaderByNumber returns the header of blockNumber, nil means the latest block
+func (b *Batcher) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*types.Header, error) {
+	var header *gethtypes.Header
+	if err := b.do(ctx, "eth_getBlockByNumber", &header, types.ToBlockNumArg(blockNumber), false); err != nil {
+		return nil, err
+	}
+
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return &types.Header{
+		Hash:       header.Hash(),
+		ParentHash: header.ParentHash,
+		Number:     header.Number,
+		Time:       header.Time,
+	}, nil
+}
+
+// Flush sends the queued requests without waiting for the flush window
+func (b *Batcher) Flush() {
+	b.mu.Lock()
+	items := b.takePending()
+	b.mu.Unlock()
+
+	b.send(items)
+}
+
+// do queues one request and waits until its batch has been sent or ctx is done
+func (b *Batcher) do(ctx context.Context, method string, result interface{}, args ...interface{}) error {
+	item := &batchItem{
+		elem: types.BatchElem{
+			Method: method,
+			Args:   args,
+			Result: result,
+		},
+		done: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, item)
+	switch {
+	case len(b.pending) >= b.maxSize:
+		items := b.takePending()
+		b.mu.Unlock()
+		go b.send(items)
+	case len(b.pending) == 1:
+		b.timer = time.AfterFunc(b.flushWindow, b.Flush)
+		b.mu.Unlock()
+	default:
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-ctx.Done():
+		b.cancel(item)
+		return ctx.Err()
+	case <-item.done:
+		return item.elem.Error
+	}
+}
+
+// cancel removes item from the queue, an item already being sent is marked so its response is dropped
+func (b *Batcher) cancel(item *batchItem) {
+	item.mu.Lock()
+	item.cancelled = true
+	item.mu.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, pending := range b.pending {
+		if pending != item {
+			continue
+		}
+
+		b.pending = append(b.pending[:i], b.pending[i+1:]...)
+		if len(b.pending) == 0 {
+			b.takePending()
+		}
+		return
+	}
+}
+
+// takePending returns the queued items and stops the flush timer, b.mu must be held
+func (b *Batcher) takePending() []*batchItem {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	items := b.pending
+	b.pending = nil
+
+	return items
+}
+
+func (b *Batcher) send(items []*batchItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	// results are decoded into raw messages first, the result of a cancelled item is never written
+	results := make([]json.RawMessage, len(items))
+	elems := make([]types.BatchElem, 0, len(items))
+	for i, item := range items {
+		elems = append(elems, types.BatchElem{
+			Method: item.elem.Method,
+			Args:   item.elem.Args,
+			Result: &results[i],
+		})
+	}
+
+	err := b.batchCaller.BatchCall(ctx, elems)
+	for i, item := range items {
+		item.mu.Lock()
+		if !item.cancelled {
+			switch {
+			case err != nil:
+				item.elem.Error = err
+			case elems[i].Error != nil:
+				item.elem.Error = elems[i].Error
+			case len(results[i]) > 0:
+				item.elem.Error = json.Unmarshal(results[i], item.elem.Result)
+			}
+		}
+		item.mu.Unlock()
+
+		close(item.done)
+	}
+}
+
+func toBlockArg(blockNumber *big.Int, blockHash common.Hash) interface{} {
+	if blockHash != zeroHash {
+		return rpc.BlockNumberOrHashWithHash(blockHash, false)
+	}
+
+	return types.ToBlockNumArg(blockNumber)
+}
@@ -0,0 +1,192 @@
+package ethrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+// batchAdapter answers eth_getBalance batches with the last byte of the account as balance and records
+// the batch sizes, a batch waits for release when it is set
+type batchAdapter struct {
+	*adaptertest.Fake
+
+	release chan struct{}
+
+	mu      sync.Mutex
+	batches []int
+}
+
+func (a *batchAdapter) BatchCall(ctx context.Context, elems []types.BatchElem) error {
+	a.mu.Lock()
+	a.batches = append(a.batches, len(elems))
+	a.mu.Unlock()
+
+	if a.release != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.release:
+		}
+	}
+
+	for i := range elems {
+		account := elems[i].Args[0].(common.Address)
+		balance, err := json.Marshal((*hexutil.Big)(big.NewInt(int64(account[19]))))
+		if err != nil {
+			return err
+		}
+		elems[i].Error = json.Unmarshal(balance, elems[i].Result)
+	}
+
+	return nil
+}
+
+func (a *batchAdapter) Batches() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]int(nil), a.batches...)
+}
+
+func newBatcher(t *testing.T, adapter *batchAdapter, options ...func(*ethrpc.Batcher)) *ethrpc.Batcher {
+	t.Helper()
+
+	batcher, err := adapter.NewRpcClient(ethrpc.WithEthClientAdapter(adapter)).NewBatcher(options...)
+	if err != nil {
+		t.Fatalf("NewBatcher: %v", err)
+	}
+
+	return batcher
+}
+
+// balances queries the balances of accounts 1 to n concurrently and checks each caller got its own
+func balances(t *testing.T, ctx context.Context, batcher *ethrpc.Batcher, n int) {
+	t.Helper()
+
+	errs := make(chan error, n)
+	for i := 1; i <= n; i++ {
+		go func() {
+			balance, err := batcher.BalanceAt(ctx, common.BigToAddress(big.NewInt(int64(i))), nil)
+			if err == nil && balance.Int64() != int64(i) {
+				err = errors.New("balance of another account")
+			}
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("BalanceAt: %v", err)
+		}
+	}
+}
+
+func TestBatcherFlushOnMaxSize(t *testing.T) {
+	adapter := &batchAdapter{Fake: adaptertest.NewFake()}
+	// the flush window never expires during the test
+	batcher := newBatcher(t, adapter, ethrpc.WithBatchMaxSize(3), ethrpc.WithBatchFlushWindow(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	balances(t, ctx, batcher, 3)
+
+	if batches := adapter.Batches(); len(batches) != 1 || batches[0] != 3 {
+		t.Fatalf("batches = %v, want one batch of 3 requests", batches)
+	}
+}
+
+func TestBatcherFlushOnWindow(t *testing.T) {
+	adapter := &batchAdapter{Fake: adaptertest.NewFake()}
+	window := 100 * time.Millisecond
+	batcher := newBatcher(t, adapter, ethrpc.WithBatchFlushWindow(window))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	balances(t, ctx, batcher, 2)
+
+	if elapsed := time.Since(start); elapsed < window {
+		t.Fatalf("batch sent after %s, before the flush window of %s", elapsed, window)
+	}
+	if batches := adapter.Batches(); len(batches) != 1 || batches[0] != 2 {
+		t.Fatalf("batches = %v, want one batch of 2 requests", batches)
+	}
+}
+
+func TestBatcherCancel(t *testing.T) {
+	account := common.BigToAddress(big.NewInt(1))
+
+	t.Run("queued", func(t *testing.T) {
+		adapter := &batchAdapter{Fake: adaptertest.NewFake()}
+		batcher := newBatcher(t, adapter, ethrpc.WithBatchFlushWindow(time.Hour))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if _, err := batcher.BalanceAt(ctx, account, nil); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("BalanceAt error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		// the cancelled request left the queue and is not sent
+		batcher.Flush()
+		if batches := adapter.Batches(); len(batches) != 0 {
+			t.Fatalf("batches = %v, want none", batches)
+		}
+	})
+
+	t.Run("sending", func(t *testing.T) {
+		adapter := &batchAdapter{Fake: adaptertest.NewFake(), release: make(chan struct{})}
+		batcher := newBatcher(t, adapter, ethrpc.WithBatchMaxSize(2), ethrpc.WithBatchFlushWindow(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled := make(chan error, 1)
+		go func() {
+			_, err := batcher.BalanceAt(ctx, account, nil)
+			cancelled <- err
+		}()
+
+		other := make(chan error, 1)
+		go func() {
+			balance, err := batcher.BalanceAt(context.Background(), common.BigToAddress(big.NewInt(2)), nil)
+			if err == nil && balance.Int64() != 2 {
+				err = errors.New("balance of another account")
+			}
+			other <- err
+		}()
+
+		// the caller stops waiting while its batch is being sent
+		for len(adapter.Batches()) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+
+		select {
+		case err := <-cancelled:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("BalanceAt error = %v, want %v", err, context.Canceled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("cancelled caller still waits for the batch")
+		}
+
+		// the other request of the batch still gets its response
+		close(adapter.release)
+		if err := <-other; err != nil {
+			t.Fatalf("BalanceAt of the other request: %v", err)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package ethrpc
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/redis"
+)
+
+const (
+	DefaultLRUCacheSize = 10000
+
+	cacheKeyPrefix = "ethrpc"
+	cacheKeyLatest = "latest"
+	cacheKeyAny    = "any"
+)
+
+// DefaultImmutableMethods are the methods whose result does not depend on the block
+var DefaultImmutableMethods = []string{"decimals", "symbol", "name"}
+
+// Cache stores raw call responses, a zero ttl means the value does not expire
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CacheMiddleware answers requests from a Cache instead of calling the node. Reads pinned to a block
+// and single calls of immutable methods are cached without expiry, reads of the latest block or of a
+// block tag for LatestTTL.
+// Its Request method must run after ParseRequestMiddleware, its Response method before ParseResponseMiddleware
+type CacheMiddleware struct {
+	chainID          int64
+	cache            Cache
+	latestTTL        time.Duration
+	immutableMethods map[string]struct{}
+}
+
+// WithLatestTTL sets how long reads of the latest block and block tags are cached, zero disables their caching
+func WithLatestTTL(ttl time.Duration) func(*CacheMiddleware) {
+	return func(m *CacheMiddleware) {
+		m.latestTTL = ttl
+	}
+}
+
+// WithImmutableMethods replaces the methods whose result is cached regardless of the block
+func WithImmutableMethods(methods ...string) func(*CacheMiddleware) {
+	return func(m *CacheMiddleware) {
+		m.immutableMethods = make(map[string]struct{}, len(methods))
+		for _, method := range methods {
+			m.immutableMethods[method] = struct{}{}
+		}
+	}
+}
+
+func NewCacheMiddleware(chainID int64, cache Cache, options ...func(*CacheMiddleware)) *CacheMiddleware {
+	m := &CacheMiddleware{
+		chainID: chainID,
+		cache:   cache,
+	}
+	WithImmutableMethods(DefaultImmutableMethods...)(m)
+
+	for _, o := range options {
+		o(m)
+	}
+
+	return m
+}
+
+// Request short-circuits the request with the cached response if there is one
+func (m *CacheMiddleware) Request(_ RequestExecutor, req *Request) error {
+	key, _, ok := m.key(req)
+	if !ok {
+		return nil
+	}
+
+	data, found, err := m.cache.Get(req.Context(), key)
+	if err != nil || !found {
+		// a failing cache must not fail the read
+		return nil
+	}
+
+	req.SetRawResponse(data)
+
+	return nil
+}
+
+// Response stores the raw response of a request that was not answered from the cache
+func (m *CacheMiddleware) Response(_ RequestExecutor, resp *Response) error {
+	if resp.Cached {
+		return nil
+	}
+
+	key, ttl, ok := m.key(resp.Request)
+	if !ok {
+		return nil
+	}
+
+	_ = m.cache.Set(resp.Request.Context(), key, resp.RawResponse, ttl)
+
+	return nil
+}
+
+// key returns the cache key of the request and the ttl of its response, ok is false when it must not be cached
+func (m *CacheMiddleware) key(req *Request) (string, time.Duration, bool) {
+	msg := req.RawCallMsg
+	if msg == nil || msg.To == nil || len(msg.StateOverride) > 0 {
+		// the result of a call with a state override does not reflect the chain
+		return "", 0, false
+	}
+
+	var (
+		block string
+		ttl   time.Duration
+	)
+
+	switch {
+	case m.isImmutable(req):
+		block = cacheKeyAny
+	case req.BlockHash != zeroHash:
+		block = req.BlockHash.Hex()
+	case req.BlockNumber != nil && req.BlockNumber.Sign() >= 0:
+		block = req.BlockNumber.String()
+	default:
+		if m.latestTTL <= 0 {
+			return "", 0, false
+		}
+		// the block tags, e.g. finalized or pending, move like latest but each to its own block
+		block = cacheKeyLatest
+		if req.BlockNumber != nil {
+			block = rpc.BlockNumber(req.BlockNumber.Int64()).String()
+		}
+		ttl = m.latestTTL
+	}
+
+	value := "0"
+	if msg.Value != nil {
+		value = msg.Value.String()
+	}
+
+	return redis.FormatKey(
+		cacheKeyPrefix,
+		strconv.FormatInt(m.chainID, 10),
+		block,
+		msg.From.Hex(),
+		msg.To.Hex(),
+		value,
+		crypto.Keccak256Hash(msg.Data).Hex(),
+	), ttl, true
+}
+
+// isImmutable reports whether req is a single call of an immutable method, a multicall is never
+// immutable since its response holds the block number
+func (m *CacheMiddleware) isImmutable(req *Request) bool {
+	if req.Method != RequestMethodCall || len(req.Calls) != 1 {
+		return false
+	}
+
+	_, ok := m.immutableMethods[req.Calls[0].Method]
+
+	return ok
+}
+
+// LRUCache is an in-process Cache evicting the least recently used entries
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = DefaultLRUCacheSize
+	}
+
+	return &LRUCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func (c *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+func (c *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+
+	return nil
+}
+
+// RedisCache is a Cache shared between processes through Redis
+type RedisCache struct {
+	client goredis.UniversalClient
+}
+
+func NewRedisCache(client goredis.UniversalClient) *RedisCache {
+	return &RedisCache{
+		client: client,
+	}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
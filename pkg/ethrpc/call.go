@@ -1,6 +1,8 @@
 package ethrpc
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
@@ -11,6 +13,12 @@ type Call struct {
 	Method    string
 	Params    []any
 	Output    []any
+
+	// AllowFailure marks the call as optional in aggregate3 requests
+	AllowFailure bool
+
+	// Value is the wei sent along with the call in aggregate3Value requests
+	Value *big.Int
 }
 
 func (c *Call) SetOutput(output []any) *Call {
@@ -19,6 +27,18 @@ func (c *Call) SetOutput(output []any) *Call {
 	return c
 }
 
+func (c *Call) SetAllowFailure(allowFailure bool) *Call {
+	c.AllowFailure = allowFailure
+
+	return c
+}
+
+func (c *Call) SetValue(value *big.Int) *Call {
+	c.Value = value
+
+	return c
+}
+
 // autofillUnpackABI fills the call's UnpackABI in case it's not set
 func (c *Call) autofillUnpackABI() {
 	if c.UnpackABI == nil {
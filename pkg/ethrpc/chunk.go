@@ -0,0 +1,143 @@
+package ethrpc
+
+import (
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	DefaultMulticallWorkers = 4
+
+	// multicallCallOverhead is the encoded size of one multicall (address, bytes) tuple without its calldata
+	multicallCallOverhead = 4 * 32
+)
+
+// WithMulticallChunking splits aggregate requests holding more than maxCalls calls or more than
+// maxDataSize bytes of encoded calldata into chunks executed by up to workers concurrent calls,
+// a zero limit is ignored
+func WithMulticallChunking(maxCalls int, maxDataSize int, workers int) func(*Client) {
+	return func(client *Client) {
+		client.options.MulticallChunkSize = maxCalls
+		client.options.MulticallChunkDataSize = maxDataSize
+		client.options.MulticallWorkers = workers
+	}
+}
+
+func isMulticallMethod(method RequestMethod) bool {
+	switch method {
+	case RequestMethodAggregate,
+		RequestMethodTryBlockAndAggregate,
+		RequestMethodAggregate3,
+		RequestMethodAggregate3Value:
+		return true
+	default:
+		return false
+	}
+}
+
+// chunkCalls splits calls by the client's chunking limits, it returns a single chunk when no limit is hit
+func (a *Client) chunkCalls(calls []*Call) ([][]*Call, error) {
+	maxCalls := a.options.MulticallChunkSize
+	maxDataSize := a.options.MulticallChunkDataSize
+
+	if maxCalls <= 0 && maxDataSize <= 0 {
+		return [][]*Call{calls}, nil
+	}
+
+	var (
+		chunks   [][]*Call
+		chunk    []*Call
+		dataSize int
+	)
+
+	for _, c := range calls {
+		size := 0
+		if maxDataSize > 0 {
+			callData, err := c.ABI.Pack(c.Method, c.Params...)
+			if err != nil {
+				return nil, err
+			}
+			size = multicallCallOverhead + (len(callData)+31)/32*32
+		}
+
+		if len(chunk) > 0 &&
+			((maxCalls > 0 && len(chunk) >= maxCalls) || (maxDataSize > 0 && dataSize+size > maxDataSize)) {
+			chunks = append(chunks, chunk)
+			chunk = nil
+			dataSize = 0
+		}
+
+		chunk = append(chunk, c)
+		dataSize += size
+	}
+
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// executeChunks executes the chunks as separate requests pinned to one block and merges their
+// responses in the original call order. The first failed chunk cancels the others and the merged
+// response has no RawResponse, see Response.RawResponse
+func (a *Client) executeChunks(req *Request, chunks [][]*Call) (*Response, error) {
+	blockNumber := req.BlockNumber
+	if blockNumber == nil && req.BlockHash == zeroHash {
+		header, err := a.adapter.HeaderByNumber(req.Context(), nil)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = header.Number
+	}
+
+	workers := a.options.MulticallWorkers
+	if workers <= 0 {
+		workers = DefaultMulticallWorkers
+	}
+
+	responses := make([]*Response, len(chunks))
+
+	eg, ctx := errgroup.WithContext(req.Context())
+	eg.SetLimit(workers)
+	for i, chunk := range chunks {
+		chunkReq := &Request{
+			executor:       a,
+			Method:         req.Method,
+			RequireSuccess: req.RequireSuccess,
+			Calls:          chunk,
+			ctx:            ctx,
+			BlockNumber:    blockNumber,
+			BlockHash:      req.BlockHash,
+			StateOverride:  req.StateOverride,
+		}
+
+		eg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			resp, err := a.execute(chunkReq)
+			if err != nil {
+				return err
+			}
+
+			responses[i] = resp
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	resp := &Response{
+		Request:     req,
+		BlockNumber: responses[0].BlockNumber,
+	}
+	for _, chunkResp := range responses {
+		resp.Result = append(resp.Result, chunkResp.Result...)
+		resp.CallResults = append(resp.CallResults, chunkResp.CallResults...)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+package ethrpc_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/erc20"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+// chunkCount is the number of chunks of 2 calls the chunking tests split their request into
+const chunkCount = 4
+
+// blockAdapter records the block of every call. The first call mines a block and is answered last,
+// so the head moves while the chunks are executed and they complete out of order
+type blockAdapter struct {
+	*adaptertest.Fake
+
+	once   sync.Once
+	mu     sync.Mutex
+	blocks []*big.Int
+	hashes []common.Hash
+}
+
+func (a *blockAdapter) CallContract(ctx context.Context, msg *types.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	a.mu.Lock()
+	a.blocks = append(a.blocks, blockNumber)
+	a.mu.Unlock()
+
+	a.firstCall()
+
+	return a.Fake.CallContract(ctx, msg, blockNumber)
+}
+
+func (a *blockAdapter) CallContractAtHash(ctx context.Context, msg *types.CallMsg, blockHash common.Hash) ([]byte, error) {
+	a.mu.Lock()
+	a.hashes = append(a.hashes, blockHash)
+	a.mu.Unlock()
+
+	a.firstCall()
+
+	return a.Fake.CallContractAtHash(ctx, msg, blockHash)
+}
+
+func (a *blockAdapter) firstCall() {
+	a.once.Do(func() {
+		a.Mine()
+		time.Sleep(50 * time.Millisecond)
+	})
+}
+
+// newChunkedRequest returns a request for the balances of chunkCount*2 tokens, each token holding its
+// index plus one, split into chunks of 2 calls
+func newChunkedRequest(t *testing.T) (*blockAdapter, *ethrpc.Request, []*big.Int) {
+	t.Helper()
+
+	adapter := &blockAdapter{Fake: adaptertest.NewFake()}
+	for i := 0; i < 3; i++ {
+		adapter.Mine()
+	}
+
+	client := adapter.NewRpcClient(
+		ethrpc.WithEthClientAdapter(adapter),
+		ethrpc.WithMulticallChunking(2, 0, chunkCount),
+	)
+	req := client.NewRequest()
+
+	holder := common.HexToAddress("0x01")
+	balances := make([]*big.Int, chunkCount*2)
+	for i := range balances {
+		target := common.BigToAddress(big.NewInt(int64(0x100 + i)))
+		if err := adapter.OnMethod(target, erc20.ABI, "balanceOf", []interface{}{holder}, big.NewInt(int64(i+1))); err != nil {
+			t.Fatalf("OnMethod: %v", err)
+		}
+
+		balances[i] = new(big.Int)
+		req.AddCall(&ethrpc.Call{
+			ABI:    *erc20.ABI,
+			Target: target.Hex(),
+			Method: "balanceOf",
+			Params: []interface{}{holder},
+		}, []interface{}{&balances[i]})
+	}
+
+	return adapter, req, balances
+}
+
+func assertCallOrder(t *testing.T, resp *ethrpc.Response, balances []*big.Int) {
+	t.Helper()
+
+	if len(resp.Result) != len(balances) || len(resp.CallResults) != len(balances) {
+		t.Fatalf("%d results, %d call results, want %d", len(resp.Result), len(resp.CallResults), len(balances))
+	}
+
+	for i, balance := range balances {
+		if !resp.Result[i] || balance.Int64() != int64(i+1) {
+			t.Fatalf("call %d = %t %s, want the balance %d of its token", i, resp.Result[i], balance, i+1)
+		}
+	}
+}
+
+func TestChunkedAggregateOrderAndBlock(t *testing.T) {
+	adapter, req, balances := newChunkedRequest(t)
+
+	resp, err := req.Aggregate3()
+	if err != nil {
+		t.Fatalf("Aggregate3: %v", err)
+	}
+	assertCallOrder(t, resp, balances)
+
+	// every chunk is pinned to the head read before the first chunk, block 3
+	if len(adapter.blocks) != chunkCount {
+		t.Fatalf("%d calls, want one per chunk", len(adapter.blocks))
+	}
+	for i, block := range adapter.blocks {
+		if block == nil || block.Int64() != 3 {
+			t.Fatalf("chunk call %d at block %v, want 3", i, block)
+		}
+	}
+}
+
+func TestChunkedAggregateBlockHash(t *testing.T) {
+	adapter, req, balances := newChunkedRequest(t)
+
+	header, err := adapter.HeaderByNumber(context.Background(), big.NewInt(2))
+	if err != nil {
+		t.Fatalf("HeaderByNumber: %v", err)
+	}
+
+	resp, err := req.SetBlockHash(header.Hash).Aggregate3()
+	if err != nil {
+		t.Fatalf("Aggregate3: %v", err)
+	}
+	assertCallOrder(t, resp, balances)
+
+	if len(adapter.blocks) != 0 || len(adapter.hashes) != chunkCount {
+		t.Fatalf("%d calls by number, %d by hash, want every chunk by hash", len(adapter.blocks), len(adapter.hashes))
+	}
+	for i, hash := range adapter.hashes {
+		if hash != header.Hash {
+			t.Fatalf("chunk call %d at block %s, want %s", i, hash, header.Hash)
+		}
+	}
+}
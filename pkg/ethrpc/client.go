@@ -15,13 +15,18 @@ type Client struct {
 
 	options ClientOptions
 
-	requestMiddlewares  []RequestMiddleware
-	responseMiddlewares []ResponseMiddleware
+	requestMiddlewares   []RequestMiddleware
+	responseMiddlewares  []ResponseMiddleware
+	executionMiddlewares []ExecutionMiddleware
 }
 
 type ClientOptions struct {
 	MultiCallContractAddress common.Address
 	MultiCallABI             *abi.ABI
+
+	MulticallChunkSize     int
+	MulticallChunkDataSize int
+	MulticallWorkers       int
 }
 
 func NewClient(options ...func(*Client)) *Client {
@@ -59,6 +64,15 @@ func WithResponseMiddlewares(middlewares ...ResponseMiddleware) func(*Client) {
 	}
 }
 
+// WithExecutionMiddlewares wraps every node call of the client, including calls sent through its batchers.
+// A batched call is wrapped while it waits for its batch, so the latency observed by the metrics,
+// logging and tracing middlewares includes the queueing delay of up to the flush window
+func WithExecutionMiddlewares(middlewares ...ExecutionMiddleware) func(*Client) {
+	return func(adapter *Client) {
+		adapter.executionMiddlewares = middlewares
+	}
+}
+
 func (a *Client) NewRequest() *Request {
 	return &Request{
 		executor: a,
@@ -66,29 +80,58 @@ func (a *Client) NewRequest() *Request {
 }
 
 func (a *Client) Execute(req *Request) (*Response, error) {
-	for _, f := range a.requestMiddlewares {
-		if err := f(a, req); err != nil {
+	if isMulticallMethod(req.Method) {
+		chunks, err := a.chunkCalls(req.Calls)
+		if err != nil {
 			return nil, err
 		}
+
+		if len(chunks) > 1 {
+			return a.executeChunks(req, chunks)
+		}
 	}
 
-	rawResponse, err := a.callContract(req)
-	if err != nil {
-		return nil, err
+	return a.execute(req)
+}
+
+func (a *Client) execute(req *Request) (*Response, error) {
+	return a.executeWith(a, req, a.callContract)
+}
+
+// executeWith runs the request and response middlewares of the client with executor around call,
+// which is wrapped in the execution middlewares and skipped when a request middleware set the response
+func (a *Client) executeWith(executor RequestExecutor, req *Request, call CallFunc) (*Response, error) {
+	for _, f := range a.requestMiddlewares {
+		if err := f(executor, req); err != nil {
+			return nil, err
+		}
 	}
 
 	resp := &Response{
 		Request:     req,
-		RawResponse: rawResponse,
+		RawResponse: req.rawResponse,
+		Cached:      req.rawResponse != nil,
+	}
+
+	if !resp.Cached {
+		if req.RawCallMsg == nil {
+			return nil, ErrWrongCallParams
+		}
+
+		rawResponse, err := chainExecutionMiddlewares(call, a.executionMiddlewares)(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.RawResponse = rawResponse
 	}
 
 	for _, f := range a.responseMiddlewares {
-		if executeErr := f(a, resp); executeErr != nil {
+		if executeErr := f(executor, resp); executeErr != nil {
 			return nil, executeErr
 		}
 	}
 
-	return resp, err
+	return resp, nil
 }
 
 func (a *Client) GetMulticallContractAddress() common.Address {
@@ -0,0 +1,181 @@
+package ethrpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+const (
+	metricsNamespace = "ethrpc"
+
+	labelChainID = "chain_id"
+	labelMethod  = "method"
+)
+
+// DefaultLatencyBuckets are the latency histogram buckets in seconds
+var DefaultLatencyBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics holds the node call collectors shared by the clients of every chain
+type Metrics struct {
+	latency *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+}
+
+// NewMetrics registers the node call latency histogram and error counter, collectors already
+// registered by another Metrics are reused
+func NewMetrics(registerer prometheus.Registerer, buckets ...float64) (*Metrics, error) {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "call_duration_seconds",
+		Help:      "Latency of node calls by chain and method",
+		Buckets:   buckets,
+	}, []string{labelChainID, labelMethod})
+	if err := registerer.Register(latency); err != nil {
+		var registeredErr prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registeredErr) {
+			return nil, err
+		}
+		latency = registeredErr.ExistingCollector.(*prometheus.HistogramVec)
+	}
+
+	callErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "call_errors_total",
+		Help:      "Failed node calls by chain and method",
+	}, []string{labelChainID, labelMethod})
+	if err := registerer.Register(callErrors); err != nil {
+		var registeredErr prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registeredErr) {
+			return nil, err
+		}
+		callErrors = registeredErr.ExistingCollector.(*prometheus.CounterVec)
+	}
+
+	return &Metrics{
+		latency: latency,
+		errors:  callErrors,
+	}, nil
+}
+
+// Middleware observes the latency and errors of the node calls of chainID
+func (m *Metrics) Middleware(chainID int64) ExecutionMiddleware {
+	chain := strconv.FormatInt(chainID, 10)
+
+	return func(next CallFunc) CallFunc {
+		return func(req *Request) ([]byte, error) {
+			method := requestMethodName(req)
+			start := time.Now()
+
+			data, err := next(req)
+
+			m.latency.WithLabelValues(chain, method).Observe(time.Since(start).Seconds())
+			if err != nil {
+				m.errors.WithLabelValues(chain, method).Inc()
+			}
+
+			return data, err
+		}
+	}
+}
+
+// LoggingMiddleware logs failed node calls and calls slower than slowThreshold as warnings,
+// other calls at debug level. A zero slowThreshold disables slow call warnings
+func LoggingMiddleware(chainID int64, slowThreshold time.Duration) ExecutionMiddleware {
+	return func(next CallFunc) CallFunc {
+		return func(req *Request) ([]byte, error) {
+			ctx := req.Context()
+			method := requestMethodName(req)
+			start := time.Now()
+
+			data, err := next(req)
+
+			elapsed := time.Since(start)
+			switch {
+			case err != nil:
+				logger.Warnf(ctx, "[ethrpc] Call %s on chainID %d at block %s failed after %s, err: %s",
+					method, chainID, requestBlock(req), elapsed, err)
+			case slowThreshold > 0 && elapsed >= slowThreshold:
+				logger.Warnf(ctx, "[ethrpc] Slow call %s on chainID %d at block %s took %s",
+					method, chainID, requestBlock(req), elapsed)
+			default:
+				logger.Debugf(ctx, "[ethrpc] Call %s on chainID %d at block %s took %s",
+					method, chainID, requestBlock(req), elapsed)
+			}
+
+			return data, err
+		}
+	}
+}
+
+// Tracer starts trace spans, it is implemented on top of the tracing backend in use
+type Tracer interface {
+	// Start starts a span that is a child of the span in ctx, the returned context carries the new span
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+// TracingMiddleware wraps every node call of chainID in a span, the call receives a copy of the
+// request whose context carries the span so adapters can propagate it
+func TracingMiddleware(chainID int64, tracer Tracer) ExecutionMiddleware {
+	chain := strconv.FormatInt(chainID, 10)
+
+	return func(next CallFunc) CallFunc {
+		return func(req *Request) ([]byte, error) {
+			attributes := map[string]string{
+				labelChainID: chain,
+				labelMethod:  requestMethodName(req),
+				"block":      requestBlock(req),
+				"calls":      strconv.Itoa(len(req.Calls)),
+			}
+			if req.RawCallMsg != nil && req.RawCallMsg.To != nil {
+				attributes["to"] = req.RawCallMsg.To.Hex()
+			}
+
+			ctx, span := tracer.Start(req.Context(), "ethrpc."+requestMethodName(req), attributes)
+			defer span.End()
+
+			// the call gets a copy carrying the span, the request of the caller is left untouched
+			spanReq := *req
+			spanReq.ctx = ctx
+			data, err := next(&spanReq)
+
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return data, err
+		}
+	}
+}
+
+// requestMethodName names a single call request after its contract method, other requests after the request method
+func requestMethodName(req *Request) string {
+	if req.Method == RequestMethodCall && len(req.Calls) == 1 {
+		return req.Calls[0].Method
+	}
+
+	return string(req.Method)
+}
+
+func requestBlock(req *Request) string {
+	if req.BlockHash != zeroHash {
+		return req.BlockHash.Hex()
+	}
+
+	return types.ToBlockNumArg(req.BlockNumber)
+}
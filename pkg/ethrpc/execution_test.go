@@ -0,0 +1,213 @@
+package ethrpc_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/erc20"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+const chainID = 1
+
+var (
+	token       = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	brokenToken = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	errNode = errors.New("node unavailable")
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func newFake(t *testing.T) *adaptertest.Fake {
+	t.Helper()
+
+	fake := adaptertest.NewFake()
+	if err := fake.OnMethod(token, erc20.ABI, "decimals", nil, uint8(6)); err != nil {
+		t.Fatalf("OnMethod: %v", err)
+	}
+
+	data, err := erc20.ABI.Pack("decimals")
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	fake.OnCallError(brokenToken, data, errNode)
+
+	return fake
+}
+
+func decimals(client *ethrpc.Client, target common.Address) (uint8, error) {
+	return ethrpc.CallTyped[uint8](context.Background(), client, erc20.ABI, target.Hex(), "decimals")
+}
+
+func TestExecutionMiddlewaresOrder(t *testing.T) {
+	var order []string
+	record := func(name string) ethrpc.ExecutionMiddleware {
+		return func(next ethrpc.CallFunc) ethrpc.CallFunc {
+			return func(req *ethrpc.Request) ([]byte, error) {
+				order = append(order, name+" before")
+				data, err := next(req)
+				order = append(order, name+" after")
+
+				return data, err
+			}
+		}
+	}
+
+	client := newFake(t).NewRpcClient(ethrpc.WithExecutionMiddlewares(
+		record("outer"),
+		ethrpc.LoggingMiddleware(chainID, time.Second),
+		record("inner"),
+	))
+
+	value, err := decimals(client, token)
+	if err != nil || value != 6 {
+		t.Fatalf("decimals = %d, %v, want 6", value, err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	metrics, err := ethrpc.NewMetrics(registry)
+	if err != nil {
+		t.Fatalf("NewMetrics: %v", err)
+	}
+
+	// a second Metrics on the same registry reuses the collectors
+	if _, err := ethrpc.NewMetrics(registry); err != nil {
+		t.Fatalf("second NewMetrics: %v", err)
+	}
+
+	client := newFake(t).NewRpcClient(ethrpc.WithExecutionMiddlewares(metrics.Middleware(chainID)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := decimals(client, token); err != nil {
+			t.Fatalf("decimals: %v", err)
+		}
+	}
+	if _, err := decimals(client, brokenToken); !errors.Is(err, errNode) {
+		t.Fatalf("decimals of broken token error = %v, want %v", err, errNode)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	counts := make(map[string]uint64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch family.GetName() {
+			case "ethrpc_call_duration_seconds":
+				counts[family.GetName()] += metric.GetHistogram().GetSampleCount()
+			case "ethrpc_call_errors_total":
+				counts[family.GetName()] += uint64(metric.GetCounter().GetValue())
+			}
+
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "method" && label.GetValue() != "decimals" {
+					t.Errorf("method label = %s, want decimals", label.GetValue())
+				}
+			}
+		}
+	}
+
+	want := map[string]uint64{
+		"ethrpc_call_duration_seconds": 3,
+		"ethrpc_call_errors_total":     1,
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Fatalf("metric counts = %v, want %v", counts, want)
+	}
+}
+
+type spanKey struct{}
+
+type recordingTracer struct {
+	names []string
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	err   error
+	ended bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, _ map[string]string) (context.Context, ethrpc.Span) {
+	span := &recordingSpan{}
+	t.names = append(t.names, name)
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *recordingSpan) End() {
+	s.ended = true
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tracer := &recordingTracer{}
+
+	var called *ethrpc.Request
+	client := newFake(t).NewRpcClient(ethrpc.WithExecutionMiddlewares(
+		ethrpc.TracingMiddleware(chainID, tracer),
+		func(next ethrpc.CallFunc) ethrpc.CallFunc {
+			return func(req *ethrpc.Request) ([]byte, error) {
+				called = req
+				return next(req)
+			}
+		},
+	))
+
+	var value uint8
+	req := client.NewRequest().AddCall(&ethrpc.Call{
+		ABI:    *erc20.ABI,
+		Target: token.Hex(),
+		Method: "decimals",
+	}, []any{&value})
+	if _, err := req.Call(); err != nil || value != 6 {
+		t.Fatalf("decimals = %d, %v, want 6", value, err)
+	}
+
+	if len(tracer.spans) != 1 || tracer.names[0] != "ethrpc.decimals" || !tracer.spans[0].ended {
+		t.Fatalf("spans = %v %+v, want one ended ethrpc.decimals span", tracer.names, tracer.spans)
+	}
+	if called == req || called.Context().Value(spanKey{}) != tracer.spans[0] {
+		t.Fatal("the call did not get a copy of the request carrying the span")
+	}
+	if req.Context().Value(spanKey{}) != nil {
+		t.Fatal("the request of the caller carries the span")
+	}
+
+	if _, err := decimals(client, brokenToken); !errors.Is(err, errNode) {
+		t.Fatalf("decimals of broken token error = %v, want %v", err, errNode)
+	}
+	if len(tracer.spans) != 2 || !errors.Is(tracer.spans[1].err, errNode) || !tracer.spans[1].ended {
+		t.Fatalf("failed call span = %+v, want an ended span recording %v", tracer.spans[1], errNode)
+	}
+}
@@ -10,3 +10,8 @@ type RequestExecutor interface {
 	GetMulticallContractAddress() common.Address
 	GetMulticallABI() *abi.ABI
 }
+
+// RequestFactory creates requests, it is implemented by Client and Batcher
+type RequestFactory interface {
+	NewRequest() *Request
+}
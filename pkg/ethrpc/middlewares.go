@@ -6,8 +6,23 @@ type (
 
 	// ResponseMiddleware type is for response middleware, called after a response has been received
 	ResponseMiddleware func(RequestExecutor, *Response) error
+
+	// CallFunc sends the call message of a request to the node and returns the raw response
+	CallFunc func(*Request) ([]byte, error)
+
+	// ExecutionMiddleware type is for execution middleware, wrapping the node call of a request
+	ExecutionMiddleware func(next CallFunc) CallFunc
 )
 
+// chainExecutionMiddlewares wraps call with the middlewares, the first middleware is the outermost
+func chainExecutionMiddlewares(call CallFunc, middlewares []ExecutionMiddleware) CallFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		call = middlewares[i](call)
+	}
+
+	return call
+}
+
 func ParseRequestMiddleware(executor RequestExecutor, req *Request) error {
 	parser, err := GetRequestParser(req.Method)
 	if err != nil {
@@ -19,6 +19,10 @@ type Request struct {
 
 	BlockNumber *big.Int
 	BlockHash   common.Hash
+
+	StateOverride types.StateOverride
+
+	rawResponse []byte
 }
 
 type RequestMethod string
@@ -27,6 +31,8 @@ var (
 	RequestMethodCall                 RequestMethod = "call"
 	RequestMethodAggregate            RequestMethod = "aggregate"
 	RequestMethodTryBlockAndAggregate RequestMethod = "tryBlockAndAggregate"
+	RequestMethodAggregate3           RequestMethod = "aggregate3"
+	RequestMethodAggregate3Value      RequestMethod = "aggregate3Value"
 )
 
 // Context method returns the Context if it's already set in request
@@ -76,6 +82,22 @@ func (r *Request) SetBlockHash(blockHash common.Hash) *Request {
 	return r
 }
 
+// SetStateOverride replaces the state of accounts while the request is executed, for multicall
+// requests the override applies to every call
+func (r *Request) SetStateOverride(stateOverride types.StateOverride) *Request {
+	r.StateOverride = stateOverride
+
+	return r
+}
+
+// SetRawResponse answers the request with data instead of calling the node,
+// it is meant for request middlewares such as CacheMiddleware
+func (r *Request) SetRawResponse(data []byte) *Request {
+	r.rawResponse = data
+
+	return r
+}
+
 func (r *Request) Execute(method RequestMethod) (*Response, error) {
 	r.Method = method
 
@@ -93,3 +115,13 @@ func (r *Request) Aggregate() (*Response, error) {
 func (r *Request) TryBlockAndAggregate() (*Response, error) {
 	return r.Execute(RequestMethodTryBlockAndAggregate)
 }
+
+// Aggregate3 executes the calls with aggregate3, only calls with AllowFailure set may fail
+func (r *Request) Aggregate3() (*Response, error) {
+	return r.Execute(RequestMethodAggregate3)
+}
+
+// Aggregate3Value executes the calls with aggregate3Value, sending the sum of the call values to the multicall
+func (r *Request) Aggregate3Value() (*Response, error) {
+	return r.Execute(RequestMethodAggregate3Value)
+}
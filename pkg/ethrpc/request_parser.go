@@ -3,6 +3,7 @@ package ethrpc
 import (
 	"fmt"
 	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -33,6 +34,8 @@ func init() {
 	RegisterRequestParser(RequestMethodCall, RequestParserCall)
 	RegisterRequestParser(RequestMethodAggregate, RequestParserAggregate)
 	RegisterRequestParser(RequestMethodTryBlockAndAggregate, RequestParserTryBlockAndAggregate)
+	RegisterRequestParser(RequestMethodAggregate3, RequestParserAggregate3)
+	RegisterRequestParser(RequestMethodAggregate3Value, RequestParserAggregate3Value)
 }
 
 type RequestParser func(executor RequestExecutor, req *Request) error
@@ -52,8 +55,9 @@ func RequestParserCall(_ RequestExecutor, req *Request) error {
 	target := common.HexToAddress(call.Target)
 
 	req.RawCallMsg = &types.CallMsg{
-		To:   &target,
-		Data: data,
+		To:            &target,
+		Data:          data,
+		StateOverride: req.StateOverride,
 	}
 
 	return nil
@@ -88,7 +92,7 @@ func RequestParserAggregate(executor RequestExecutor, req *Request) error {
 		return err
 	}
 
-	msg := &types.CallMsg{To: &multicallContractAddress, Data: data}
+	msg := &types.CallMsg{To: &multicallContractAddress, Data: data, StateOverride: req.StateOverride}
 	req.RawCallMsg = msg
 
 	return nil
@@ -122,7 +126,82 @@ func RequestParserTryBlockAndAggregate(executor RequestExecutor, req *Request) e
 		return err
 	}
 
-	msg := &types.CallMsg{To: &multicallContractAddress, Data: data}
+	msg := &types.CallMsg{To: &multicallContractAddress, Data: data, StateOverride: req.StateOverride}
+	req.RawCallMsg = msg
+
+	return nil
+}
+
+// RequestParserAggregate3 parse raw call msg for RequestMethodAggregate3
+func RequestParserAggregate3(executor RequestExecutor, req *Request) error {
+	multicallContractAddress := executor.GetMulticallContractAddress()
+	multicallABI := executor.GetMulticallABI()
+
+	var multiCallParamList []MultiCall3Param
+
+	for _, call := range req.Calls {
+		callData, err := call.ABI.Pack(call.Method, call.Params...)
+		if err != nil {
+			return err
+		}
+
+		multiCallParamList = append(
+			multiCallParamList, MultiCall3Param{
+				Target:       common.HexToAddress(call.Target),
+				AllowFailure: call.AllowFailure,
+				CallData:     callData,
+			},
+		)
+	}
+
+	data, err := multicallABI.Pack(string(RequestMethodAggregate3), multiCallParamList)
+	if err != nil {
+		return err
+	}
+
+	msg := &types.CallMsg{To: &multicallContractAddress, Data: data, StateOverride: req.StateOverride}
+	req.RawCallMsg = msg
+
+	return nil
+}
+
+// RequestParserAggregate3Value parse raw call msg for RequestMethodAggregate3Value,
+// the msg value is the sum of the call values
+func RequestParserAggregate3Value(executor RequestExecutor, req *Request) error {
+	multicallContractAddress := executor.GetMulticallContractAddress()
+	multicallABI := executor.GetMulticallABI()
+
+	var multiCallParamList []MultiCall3ValueParam
+
+	totalValue := new(big.Int)
+	for _, call := range req.Calls {
+		callData, err := call.ABI.Pack(call.Method, call.Params...)
+		if err != nil {
+			return err
+		}
+
+		value := call.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		totalValue.Add(totalValue, value)
+
+		multiCallParamList = append(
+			multiCallParamList, MultiCall3ValueParam{
+				Target:       common.HexToAddress(call.Target),
+				AllowFailure: call.AllowFailure,
+				Value:        value,
+				CallData:     callData,
+			},
+		)
+	}
+
+	data, err := multicallABI.Pack(string(RequestMethodAggregate3Value), multiCallParamList)
+	if err != nil {
+		return err
+	}
+
+	msg := &types.CallMsg{To: &multicallContractAddress, Data: data, Value: totalValue, StateOverride: req.StateOverride}
 	req.RawCallMsg = msg
 
 	return nil
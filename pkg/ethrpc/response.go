@@ -5,10 +5,56 @@ import (
 )
 
 type Response struct {
-	Request     *Request
+	Request *Request
+
+	// RawResponse is the return data of the eth_call. It is nil when a multicall request was split into
+	// chunks, each chunk is a separate aggregate call and their return data can not be merged into one
 	RawResponse []byte
 	BlockNumber *big.Int
 
-	// Result is an array that contains response result for all calls in the request
+	// Cached is true when the raw response was set by a request middleware instead of the node
+	Cached bool
+
+	// Result is an array that contains response result for all calls in the request,
+	// a call is successful when it did not revert and its return data was unpacked into its output
 	Result []bool
+
+	// CallResults contains the detailed result of every call in the request, in the call order
+	CallResults []CallResult
+}
+
+// CallResult is the outcome of one call of a multicall request
+type CallResult struct {
+	// Success is false when the call reverted
+	Success    bool
+	ReturnData []byte
+
+	// RevertReason is the decoded Error(string), Panic(uint256) or custom error of a reverted call
+	RevertReason string
+
+	// UnpackErr is set when the return data of a successful call could not be unpacked into its output
+	UnpackErr error
+}
+
+// Err returns the error of the call, nil if it succeeded and was unpacked
+func (r CallResult) Err() error {
+	if !r.Success {
+		return &RevertError{Reason: r.RevertReason, Data: r.ReturnData}
+	}
+
+	return r.UnpackErr
+}
+
+// RevertError is returned by CallResult.Err for reverted calls
+type RevertError struct {
+	Reason string
+	Data   []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+
+	return "execution reverted: " + e.Reason
 }
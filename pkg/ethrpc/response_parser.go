@@ -33,6 +33,8 @@ func init() {
 	RegisterResponseParser(RequestMethodCall, ResponseParserCall)
 	RegisterResponseParser(RequestMethodAggregate, ResponseParserAggregate)
 	RegisterResponseParser(RequestMethodTryBlockAndAggregate, ResponseParserTryBlockAndAggregate)
+	RegisterResponseParser(RequestMethodAggregate3, ResponseParserAggregate3)
+	RegisterResponseParser(RequestMethodAggregate3Value, ResponseParserAggregate3)
 }
 
 type ResponseParser func(executor RequestExecutor, req *Response) error
@@ -71,6 +73,7 @@ func ResponseParserAggregate(executor RequestExecutor, resp *Response) error {
 	for i, c := range resp.Request.Calls {
 		// result will always be true if it can reach this far
 		resp.Result = append(resp.Result, true)
+		resp.CallResults = append(resp.CallResults, CallResult{Success: true, ReturnData: result.ReturnData[i]})
 
 		if err := c.ABI.UnpackIntoInterface(c.Output[0], c.Method, result.ReturnData[i]); err != nil {
 			return fmt.Errorf("%w: %w", ErrUnpackMulticallFailed, err)
@@ -93,32 +96,81 @@ func ResponseParserTryBlockAndAggregate(executor RequestExecutor, resp *Response
 		return err
 	}
 
-	for i, c := range resp.Request.Calls {
-		resp.Result = append(resp.Result, result.ReturnData[i].Success)
+	if err := parseCallResults(resp, result.ReturnData); err != nil {
+		return err
+	}
 
-		if !result.ReturnData[i].Success {
-			continue
+	resp.BlockNumber = result.BlockNumber
+
+	return nil
+}
+
+// ResponseParserAggregate3 parse raw call msg for RequestMethodAggregate3 and RequestMethodAggregate3Value,
+// aggregate3 returns no block number so the response block number is the requested one
+func ResponseParserAggregate3(executor RequestExecutor, resp *Response) error {
+	var (
+		multicallABI = executor.GetMulticallABI()
+
+		result Aggregate3Result
+	)
+
+	if err := multicallABI.UnpackIntoInterface(&result, string(resp.Request.Method), resp.RawResponse); err != nil {
+		return err
+	}
+
+	if err := parseCallResults(resp, result.ReturnData); err != nil {
+		return err
+	}
+
+	resp.BlockNumber = resp.Request.BlockNumber
+
+	return nil
+}
+
+// parseCallResults unpacks the return data of every successful call, a call that fails to unpack
+// is reported in its CallResult instead of failing the whole response
+func parseCallResults(resp *Response, returnData []TryAggregateResult) error {
+	if len(returnData) != len(resp.Request.Calls) {
+		return ErrReturnDataIsNotMatched
+	}
+
+	for i, c := range resp.Request.Calls {
+		callResult := CallResult{
+			Success:    returnData[i].Success,
+			ReturnData: returnData[i].ReturnData,
 		}
 
-		if unpackSucceed := tryUnpack(c.UnpackABI, c.Output, c.Method, result.ReturnData[i].ReturnData); !unpackSucceed {
-			return ErrUnpackMulticallFailed
+		if !callResult.Success {
+			callResult.RevertReason = decodeRevert(c.UnpackABI, callResult.ReturnData)
+		} else if err := tryUnpack(c.UnpackABI, c.Output, c.Method, callResult.ReturnData); err != nil {
+			callResult.UnpackErr = err
 		}
-	}
 
-	resp.BlockNumber = result.BlockNumber
+		resp.Result = append(resp.Result, callResult.Success && callResult.UnpackErr == nil)
+		resp.CallResults = append(resp.CallResults, callResult)
+	}
 
 	return nil
 }
 
-// tryUnpack receives a list of ABIs, try to unpack and returns true if it can unpack successfully
-func tryUnpack(unpackABIs []*abi.ABI, output []any, method string, data []byte) bool {
+// tryUnpack receives a list of ABIs, try to unpack and returns nil if it can unpack successfully
+func tryUnpack(unpackABIs []*abi.ABI, output []any, method string, data []byte) error {
+	var err error
 	for i, unpackABI := range unpackABIs {
-		if err := unpackABI.UnpackIntoInterface(output[i], method, data); err != nil {
+		if i >= len(output) {
+			break
+		}
+
+		if err = unpackABI.UnpackIntoInterface(output[i], method, data); err != nil {
 			continue
 		}
 
-		return true
+		return nil
+	}
+
+	if err == nil {
+		return ErrUnpackMulticallFailed
 	}
 
-	return false
+	return fmt.Errorf("%w: %w", ErrUnpackMulticallFailed, err)
 }
@@ -0,0 +1,44 @@
+package ethrpc
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// decodeRevert decodes the revert data of a call as Error(string), Panic(uint256) or one of
+// the custom errors of the call ABIs, unknown data is returned as hex
+func decodeRevert(unpackABIs []*abi.ABI, data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	for _, unpackABI := range unpackABIs {
+		for _, abiError := range unpackABI.Errors {
+			if !bytes.Equal(abiError.ID[:4], data[:4]) {
+				continue
+			}
+
+			args, err := abiError.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+
+			formatted := make([]string, 0, len(args))
+			for _, arg := range args {
+				formatted = append(formatted, fmt.Sprintf("%v", arg))
+			}
+
+			return abiError.Name + "(" + strings.Join(formatted, ", ") + ")"
+		}
+	}
+
+	return hexutil.Encode(data)
+}
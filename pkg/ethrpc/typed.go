@@ -0,0 +1,109 @@
+package ethrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+var (
+	ErrFutureNotResolved = fmt.Errorf("future is not resolved, the batch has not been executed")
+)
+
+// CallTyped executes a single call and unpacks its output into T, a struct for methods with several outputs
+func CallTyped[T any](ctx context.Context, factory RequestFactory, contractABI *abi.ABI, target string, method string, params ...any) (T, error) {
+	var output T
+
+	_, err := factory.NewRequest().
+		SetContext(ctx).
+		AddCall(&Call{
+			ABI:    *contractABI,
+			Target: target,
+			Method: method,
+			Params: params,
+		}, []any{&output}).
+		Call()
+
+	return output, err
+}
+
+// Batch collects typed calls executed together with one TryBlockAndAggregate, every call
+// returns a Future resolved once the batch has been executed
+type Batch struct {
+	req  *Request
+	resp *Response
+}
+
+func NewBatch(factory RequestFactory) *Batch {
+	return &Batch{
+		req: factory.NewRequest(),
+	}
+}
+
+// Future is the result of a call added to a Batch
+type Future[T any] struct {
+	batch *Batch
+	index int
+	value T
+}
+
+// AddCallTyped adds a call to the batch whose output is unpacked into T
+func AddCallTyped[T any](batch *Batch, contractABI *abi.ABI, target string, method string, params ...any) *Future[T] {
+	future := &Future[T]{
+		batch: batch,
+		index: len(batch.req.Calls),
+	}
+
+	batch.req.AddCall(&Call{
+		ABI:    *contractABI,
+		Target: target,
+		Method: method,
+		Params: params,
+	}, []any{&future.value})
+
+	return future
+}
+
+func (b *Batch) SetBlockNumber(blockNumber *big.Int) *Batch {
+	b.req.SetBlockNumber(blockNumber)
+
+	return b
+}
+
+// SetStateOverride replaces the state of accounts for every call of the batch
+func (b *Batch) SetStateOverride(stateOverride types.StateOverride) *Batch {
+	b.req.SetStateOverride(stateOverride)
+
+	return b
+}
+
+// Execute executes the batch, a failing call only fails its own future
+func (b *Batch) Execute(ctx context.Context) (*Response, error) {
+	resp, err := b.req.SetContext(ctx).SetRequireSuccess(false).TryBlockAndAggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	b.resp = resp
+
+	return resp, nil
+}
+
+// Get returns the output of the call, or the revert or unpack error of the call
+func (f *Future[T]) Get() (T, error) {
+	var zero T
+
+	if f.batch.resp == nil || f.index >= len(f.batch.resp.CallResults) {
+		return zero, ErrFutureNotResolved
+	}
+
+	if err := f.batch.resp.CallResults[f.index].Err(); err != nil {
+		return zero, err
+	}
+
+	return f.value, nil
+}
@@ -11,6 +11,19 @@ type MultiCallParam struct {
 	CallData []byte
 }
 
+type MultiCall3Param struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type MultiCall3ValueParam struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
 type AggregateResult struct {
 	BlockNumber *big.Int
 	ReturnData  [][]byte
@@ -23,6 +36,10 @@ type TryAggregateResult struct {
 
 type TryAggregateResultList []TryAggregateResult
 
+type Aggregate3Result struct {
+	ReturnData []TryAggregateResult
+}
+
 type TryBlockAndAggregateResult struct {
 	BlockNumber *big.Int
 	BlockHash   [32]byte
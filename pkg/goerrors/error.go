@@ -24,6 +24,11 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("ERROR: {Code: %s, Message: %s, ErrorEntities: %v, RootCause: %v}", e.Code, e.Message, e.ErrorEntities, e.RootCause)
 }
 
+// Unwrap returns the root cause so the error works with errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.RootCause
+}
+
 func NewErrRequired(rootCause error, entities ...string) *Error {
 	return NewError(ErrCodeRequired, ErrMsgRequired, entities, rootCause)
 }
@@ -0,0 +1,18 @@
+package indexer
+
+import "time"
+
+const (
+	EventBoringVaultManaged   = "BoringVaultManaged"
+	EventManageRootUpdated    = "ManageRootUpdated"
+	EventPaused               = "Paused"
+	EventUnpaused             = "Unpaused"
+	EventOwnershipTransferred = "OwnershipTransferred"
+	EventAuthorityUpdated     = "AuthorityUpdated"
+)
+
+const (
+	DefaultBlockRange = 2000
+	DefaultReorgDepth = 64
+	DefaultInterval   = 12 * time.Second
+)
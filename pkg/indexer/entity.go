@@ -0,0 +1,64 @@
+package indexer
+
+import (
+	"github.com/Tempest-Finance/console-strategies-common/pkg/entity"
+)
+
+// ManageRootEvent is an event emitted by a ManageRoot contract, only the fields of its event type are set
+type ManageRootEvent struct {
+	entity.BaseID
+
+	ChainID     int64  `json:"chainId" gorm:"uniqueIndex:idx_manage_root_events_log"`
+	Manager     string `json:"manager" gorm:"index"`
+	Event       string `json:"event" gorm:"index"`
+	BlockNumber uint64 `json:"blockNumber" gorm:"index"`
+	BlockHash   string `json:"blockHash"`
+	TxHash      string `json:"txHash" gorm:"uniqueIndex:idx_manage_root_events_log"`
+	LogIndex    uint   `json:"logIndex" gorm:"uniqueIndex:idx_manage_root_events_log"`
+
+	// ManageRootUpdated
+	Strategist string `json:"strategist,omitempty"`
+	OldRoot    string `json:"oldRoot,omitempty"`
+	NewRoot    string `json:"newRoot,omitempty"`
+
+	// BoringVaultManaged
+	CallsMade string `json:"callsMade,omitempty"`
+
+	// OwnershipTransferred and AuthorityUpdated
+	User         string `json:"user,omitempty"`
+	NewOwner     string `json:"newOwner,omitempty"`
+	NewAuthority string `json:"newAuthority,omitempty"`
+
+	entity.BaseCreatedUpdated
+}
+
+func (ManageRootEvent) TableName() string {
+	return "manage_root_events"
+}
+
+// ManageRootCursor is the last block indexed for a manager
+type ManageRootCursor struct {
+	ChainID     int64  `json:"chainId" gorm:"primaryKey"`
+	Manager     string `json:"manager" gorm:"primaryKey"`
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockHash   string `json:"blockHash"`
+
+	entity.BaseCreatedUpdated
+}
+
+func (ManageRootCursor) TableName() string {
+	return "manage_root_cursors"
+}
+
+// ManageRootBlock is the hash of a block indexed for a manager, the last ReorgDepth blocks are kept
+// to find where the canonical chain forked from the indexed one
+type ManageRootBlock struct {
+	ChainID     int64  `json:"chainId" gorm:"primaryKey"`
+	Manager     string `json:"manager" gorm:"primaryKey"`
+	BlockNumber uint64 `json:"blockNumber" gorm:"primaryKey"`
+	BlockHash   string `json:"blockHash"`
+}
+
+func (ManageRootBlock) TableName() string {
+	return "manage_root_blocks"
+}
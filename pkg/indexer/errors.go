@@ -0,0 +1,10 @@
+package indexer
+
+import "errors"
+
+var (
+	ErrUnknownEvent = errors.New("unknown manage root event")
+	ErrNoStartBlock = errors.New("start block is required")
+	ErrReorgTooDeep = errors.New("reorg is deeper than the indexed block hashes")
+	ErrChainChanged = errors.New("chain changed while indexing")
+)
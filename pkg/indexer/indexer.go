@@ -0,0 +1,362 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	adaptertypes "github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus"
+)
+
+// Indexer backfills and follows the events of every ManageRoot contract
+// listed in the Nucleus address book for one chain and stores them in Postgres
+type Indexer struct {
+	chainID    int64
+	ethClient  adapter.EthClientAdapter
+	client     nucleus.IClient
+	repository *repository
+	filterer   *manageroot.ManageRootFilterer
+	topics     []common.Hash
+	config     Config
+}
+
+func NewIndexer(chainID int64, ethClient adapter.EthClientAdapter, client nucleus.IClient, db *gorm.DB, config Config) (*Indexer, error) {
+	filterer, err := manageroot.NewManageRootFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.StartBlock == 0 {
+		return nil, ErrNoStartBlock
+	}
+	if config.BlockRange == 0 {
+		config.BlockRange = DefaultBlockRange
+	}
+	if config.ReorgDepth == 0 {
+		config.ReorgDepth = DefaultReorgDepth
+	}
+	if config.Interval == 0 {
+		config.Interval = DefaultInterval
+	}
+
+	topics := make([]common.Hash, 0, 6)
+	for _, event := range []string{
+		EventBoringVaultManaged,
+		EventManageRootUpdated,
+		EventPaused,
+		EventUnpaused,
+		EventOwnershipTransferred,
+		EventAuthorityUpdated,
+	} {
+		topics = append(topics, manageroot.ABI.Events[event].ID)
+	}
+
+	return &Indexer{
+		chainID:    chainID,
+		ethClient:  ethClient,
+		client:     client,
+		repository: &repository{db: db},
+		filterer:   filterer,
+		topics:     topics,
+		config:     config,
+	}, nil
+}
+
+// Run syncs the managers until ctx is done
+func (i *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(i.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.Sync(ctx); err != nil {
+			logger.Warnf(ctx, "[ManageRoot Indexer] Sync chainID %d error: %s", i.chainID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes every manager of the chain up to the confirmed head
+func (i *Indexer) Sync(ctx context.Context) error {
+	head, err := i.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	if head < i.config.Confirmations {
+		return nil
+	}
+	head -= i.config.Confirmations
+
+	for _, manager := range i.managers() {
+		if err := i.syncManager(ctx, manager, head); err != nil {
+			return fmt.Errorf("failed to sync manager %s: %w", manager.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Indexer) managers() []common.Address {
+	network, ok := i.client.GetAddressBook()[i.chainID]
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[common.Address]struct{})
+	managers := make([]common.Address, 0, len(network.Nucleus.Vaults))
+	for _, vault := range network.Nucleus.Vaults {
+		if !common.IsHexAddress(vault.Manager) {
+			continue
+		}
+
+		manager := common.HexToAddress(vault.Manager)
+		if _, ok := seen[manager]; ok {
+			continue
+		}
+		seen[manager] = struct{}{}
+		managers = append(managers, manager)
+	}
+
+	return managers
+}
+
+func (i *Indexer) syncManager(ctx context.Context, manager common.Address, head uint64) error {
+	cursor, err := i.repository.getCursor(ctx, i.chainID, manager.Hex())
+	if err != nil {
+		return err
+	}
+
+	from := i.config.StartBlock
+	if cursor == nil {
+		cursor = &ManageRootCursor{ChainID: i.chainID, Manager: manager.Hex()}
+	} else {
+		if err := i.checkReorg(ctx, cursor); err != nil {
+			return err
+		}
+		from = cursor.BlockNumber + 1
+	}
+
+	for ; from <= head; from = cursor.BlockNumber + 1 {
+		to := min(from+i.config.BlockRange-1, head)
+
+		// the header closing the range is fetched before the logs, logs of a block replaced meanwhile
+		// do not match the hashes walked back from it and the range is indexed again on the next sync
+		header, err := i.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return err
+		}
+
+		blocks, err := i.walkBack(ctx, manager, header, max(from, i.windowStart(to)))
+		if err != nil {
+			return err
+		}
+
+		logs, err := i.ethClient.FilterLogs(ctx, adaptertypes.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{manager},
+			Topics:    [][]common.Hash{i.topics},
+		})
+		if err != nil {
+			return err
+		}
+
+		hashes := make(map[uint64]string, len(blocks))
+		for _, block := range blocks {
+			hashes[block.BlockNumber] = block.BlockHash
+		}
+
+		events := make([]ManageRootEvent, 0, len(logs))
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+
+			if hash, ok := hashes[log.BlockNumber]; ok && !strings.EqualFold(hash, log.BlockHash.Hex()) {
+				return fmt.Errorf("%w: log of block %d in %s, expected %s", ErrChainChanged, log.BlockNumber, log.BlockHash.Hex(), hash)
+			}
+
+			event, err := i.parseEvent(manager, log)
+			if err != nil {
+				return err
+			}
+			events = append(events, *event)
+		}
+
+		cursor.BlockNumber = to
+		cursor.BlockHash = header.Hash.Hex()
+		if err := i.repository.saveEvents(ctx, events, blocks, cursor, i.windowStart(to)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkBack follows the parent hashes from header down to block number lowest and returns their hashes
+func (i *Indexer) walkBack(ctx context.Context, manager common.Address, header *adaptertypes.Header, lowest uint64) ([]ManageRootBlock, error) {
+	blocks := []ManageRootBlock{i.toBlock(manager, header)}
+	for header.Number.Uint64() > lowest {
+		parent, err := i.ethClient.HeaderByHash(ctx, header.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+
+		header = parent
+		blocks = append(blocks, i.toBlock(manager, header))
+	}
+
+	return blocks, nil
+}
+
+func (i *Indexer) toBlock(manager common.Address, header *adaptertypes.Header) ManageRootBlock {
+	return ManageRootBlock{
+		ChainID:     i.chainID,
+		Manager:     manager.Hex(),
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash.Hex(),
+	}
+}
+
+// windowStart returns the lowest block whose hash is kept when number is indexed
+func (i *Indexer) windowStart(number uint64) uint64 {
+	if number < i.config.ReorgDepth {
+		return 0
+	}
+
+	return number - i.config.ReorgDepth + 1
+}
+
+// checkReorg compares the cursor hash with the canonical chain. On mismatch the canonical chain is walked
+// back by parent hash until it meets a kept block hash, the common ancestor, and the events above it are
+// deleted so the replaced blocks are indexed again
+func (i *Indexer) checkReorg(ctx context.Context, cursor *ManageRootCursor) error {
+	if cursor.BlockHash == "" {
+		return nil
+	}
+
+	header, err := i.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(cursor.BlockNumber))
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(header.Hash.Hex(), cursor.BlockHash) {
+		return nil
+	}
+
+	logger.Warnf(ctx, "[ManageRoot Indexer] Reorg detected for manager %s on chainID %d at block %d, expected %s, got %s",
+		cursor.Manager, cursor.ChainID, cursor.BlockNumber, cursor.BlockHash, header.Hash.Hex())
+
+	blocks, err := i.repository.getBlocks(ctx, cursor.ChainID, cursor.Manager)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if block.BlockNumber > header.Number.Uint64() {
+			continue
+		}
+
+		for header.Number.Uint64() > block.BlockNumber {
+			header, err = i.ethClient.HeaderByHash(ctx, header.ParentHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		if strings.EqualFold(header.Hash.Hex(), block.BlockHash) {
+			logger.Warnf(ctx, "[ManageRoot Indexer] Rewinding manager %s on chainID %d from block %d to common ancestor %d",
+				cursor.Manager, cursor.ChainID, cursor.BlockNumber, block.BlockNumber)
+
+			cursor.BlockNumber = block.BlockNumber
+			cursor.BlockHash = block.BlockHash
+
+			return i.repository.rewind(ctx, cursor)
+		}
+	}
+
+	return fmt.Errorf("%w: manager %s on chainID %d at block %d, %d block hashes kept",
+		ErrReorgTooDeep, cursor.Manager, cursor.ChainID, cursor.BlockNumber, len(blocks))
+}
+
+func (i *Indexer) parseEvent(manager common.Address, log adaptertypes.Log) (*ManageRootEvent, error) {
+	event := &ManageRootEvent{
+		ChainID:     i.chainID,
+		Manager:     manager.Hex(),
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash.Hex(),
+		TxHash:      log.TxHash.Hex(),
+		LogIndex:    log.Index,
+	}
+
+	rawLog := types.Log{
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        log.Data,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		BlockHash:   log.BlockHash,
+		Index:       log.Index,
+		Removed:     log.Removed,
+	}
+
+	switch log.Topics[0] {
+	case manageroot.ABI.Events[EventBoringVaultManaged].ID:
+		parsed, err := i.filterer.ParseBoringVaultManaged(rawLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = EventBoringVaultManaged
+		event.CallsMade = parsed.CallsMade.String()
+	case manageroot.ABI.Events[EventManageRootUpdated].ID:
+		parsed, err := i.filterer.ParseManageRootUpdated(rawLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = EventManageRootUpdated
+		event.Strategist = parsed.Strategist.Hex()
+		event.OldRoot = hexutil.Encode(parsed.OldRoot[:])
+		event.NewRoot = hexutil.Encode(parsed.NewRoot[:])
+	case manageroot.ABI.Events[EventPaused].ID:
+		event.Event = EventPaused
+	case manageroot.ABI.Events[EventUnpaused].ID:
+		event.Event = EventUnpaused
+	case manageroot.ABI.Events[EventOwnershipTransferred].ID:
+		parsed, err := i.filterer.ParseOwnershipTransferred(rawLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = EventOwnershipTransferred
+		event.User = parsed.User.Hex()
+		event.NewOwner = parsed.NewOwner.Hex()
+	case manageroot.ABI.Events[EventAuthorityUpdated].ID:
+		parsed, err := i.filterer.ParseAuthorityUpdated(rawLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = EventAuthorityUpdated
+		event.User = parsed.User.Hex()
+		event.NewAuthority = parsed.NewAuthority.Hex()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, log.Topics[0].Hex())
+	}
+
+	return event, nil
+}
@@ -0,0 +1,216 @@
+package indexer_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/indexer"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus/nucleustest"
+)
+
+const chainID = 1
+
+var manager = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// newIndexer returns an indexer of manager over fake, storing its rows in a sqlite database
+func newIndexer(t *testing.T, fake *adaptertest.Fake, reorgDepth uint64) (*indexer.Indexer, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "indexer.db")), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	// sqlite only accepts a function default in parentheses, the IDs are set by BeforeCreate anyway
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&indexer.ManageRootEvent{}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	id := stmt.Schema.LookUpField("ID")
+	id.DefaultValue = "(" + id.DefaultValue + ")"
+
+	if err := indexer.AutoMigrate(db); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+
+	client := nucleustest.NewClient(nil)
+	client.SetVault(chainID, "VAULT", nucleus.VaultDetail{Manager: manager.Hex()})
+
+	idx, err := indexer.NewIndexer(chainID, fake, client, db, indexer.Config{
+		StartBlock: 1,
+		ReorgDepth: reorgDepth,
+	})
+	if err != nil {
+		t.Fatalf("NewIndexer: %v", err)
+	}
+
+	return idx, db
+}
+
+// managed returns a BoringVaultManaged log of manager in header
+func managed(t *testing.T, header *types.Header, callsMade int64) types.Log {
+	t.Helper()
+
+	event := manageroot.ABI.Events[indexer.EventBoringVaultManaged]
+	data, err := event.Inputs.Pack(big.NewInt(callsMade))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	return types.Log{
+		Address:     manager,
+		Topics:      []common.Hash{event.ID},
+		Data:        data,
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash,
+		TxHash:      common.BigToHash(big.NewInt(callsMade)),
+	}
+}
+
+// fork replaces the chain above parent with n new blocks
+func fork(fake *adaptertest.Fake, parent *types.Header, n int) []*types.Header {
+	headers := make([]*types.Header, 0, n)
+	for i := 0; i < n; i++ {
+		parent = fake.AddHeader(&types.Header{
+			ParentHash: parent.Hash,
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			// a different time gives a different hash than the replaced block
+			Time: parent.Time + 13,
+		})
+		headers = append(headers, parent)
+	}
+
+	return headers
+}
+
+func events(t *testing.T, db *gorm.DB) []indexer.ManageRootEvent {
+	t.Helper()
+
+	var events []indexer.ManageRootEvent
+	if err := db.Order("block_number").Find(&events).Error; err != nil {
+		t.Fatalf("find events: %v", err)
+	}
+
+	return events
+}
+
+func cursor(t *testing.T, db *gorm.DB) indexer.ManageRootCursor {
+	t.Helper()
+
+	var cursor indexer.ManageRootCursor
+	if err := db.First(&cursor).Error; err != nil {
+		t.Fatalf("find cursor: %v", err)
+	}
+
+	return cursor
+}
+
+func TestIndexerReorg(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	var chain []*types.Header
+	for i := 0; i < 5; i++ {
+		chain = append(chain, fake.Mine())
+	}
+	fake.AddLogs(managed(t, chain[1], 1), managed(t, chain[2], 2), managed(t, chain[4], 3))
+
+	idx, db := newIndexer(t, fake, 0)
+	if err := idx.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := events(t, db); len(got) != 3 {
+		t.Fatalf("%d events indexed, want 3", len(got))
+	}
+
+	// blocks 3 to 5 are replaced by a longer chain with an event in block 4
+	reorged := fork(fake, chain[1], 4)
+	fake.AddLogs(managed(t, reorged[1], 4))
+
+	if err := idx.Sync(ctx); err != nil {
+		t.Fatalf("Sync after the reorg: %v", err)
+	}
+
+	// the events of the replaced blocks are deleted and the new chain is indexed
+	got := events(t, db)
+	if len(got) != 2 {
+		t.Fatalf("%d events after the reorg, want the event below the fork and the reorged one", len(got))
+	}
+	if got[0].BlockHash != chain[1].Hash.Hex() || got[0].CallsMade != "1" {
+		t.Fatalf("event below the fork = %+v, want it kept", got[0])
+	}
+	if got[1].BlockHash != reorged[1].Hash.Hex() || got[1].CallsMade != "4" {
+		t.Fatalf("reorged event = %+v, want the event of block %s", got[1], reorged[1].Number)
+	}
+
+	var blocks []indexer.ManageRootBlock
+	if err := db.Where("block_number > ?", 2).Order("block_number").Find(&blocks).Error; err != nil {
+		t.Fatalf("find blocks: %v", err)
+	}
+	if len(blocks) != len(reorged) {
+		t.Fatalf("%d block hashes above the fork, want %d", len(blocks), len(reorged))
+	}
+	for i, block := range blocks {
+		if block.BlockHash != reorged[i].Hash.Hex() {
+			t.Fatalf("block %d hash = %s, want the reorged %s", block.BlockNumber, block.BlockHash, reorged[i].Hash.Hex())
+		}
+	}
+
+	if got := cursor(t, db); got.BlockNumber != 6 || got.BlockHash != reorged[3].Hash.Hex() {
+		t.Fatalf("cursor = %d %s, want the reorged head", got.BlockNumber, got.BlockHash)
+	}
+}
+
+func TestIndexerReorgTooDeep(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	var chain []*types.Header
+	for i := 0; i < 6; i++ {
+		chain = append(chain, fake.Mine())
+	}
+	fake.AddLogs(managed(t, chain[5], 1))
+
+	// the hashes of blocks 5 and 6 are kept
+	idx, db := newIndexer(t, fake, 2)
+	if err := idx.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	fork(fake, chain[1], 5)
+
+	if err := idx.Sync(ctx); !errors.Is(err, indexer.ErrReorgTooDeep) {
+		t.Fatalf("Sync error = %v, want %v", err, indexer.ErrReorgTooDeep)
+	}
+
+	// nothing is rolled back without a common ancestor
+	if got := events(t, db); len(got) != 1 || got[0].BlockHash != chain[5].Hash.Hex() {
+		t.Fatalf("events = %+v, want the event of the indexed chain", got)
+	}
+	if got := cursor(t, db); got.BlockNumber != 6 || got.BlockHash != chain[5].Hash.Hex() {
+		t.Fatalf("cursor = %d %s, want the indexed head", got.BlockNumber, got.BlockHash)
+	}
+}
@@ -0,0 +1,98 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+// AutoMigrate creates or updates the tables used by the indexer
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&ManageRootEvent{}, &ManageRootCursor{}, &ManageRootBlock{})
+}
+
+func (r *repository) getCursor(ctx context.Context, chainID int64, manager string) (*ManageRootCursor, error) {
+	var cursor ManageRootCursor
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND manager = ?", chainID, manager).
+		First(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// getBlocks returns the block hashes kept for manager, the highest block first
+func (r *repository) getBlocks(ctx context.Context, chainID int64, manager string) ([]ManageRootBlock, error) {
+	var blocks []ManageRootBlock
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND manager = ?", chainID, manager).
+		Order("block_number DESC").
+		Find(&blocks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// saveEvents stores the events and block hashes and moves the cursor in one transaction, already
+// stored events are skipped and the hashes of the blocks below keepFrom are deleted
+func (r *repository) saveEvents(
+	ctx context.Context,
+	events []ManageRootEvent,
+	blocks []ManageRootBlock,
+	cursor *ManageRootCursor,
+	keepFrom uint64,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&events).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(blocks) > 0 {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&blocks).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.
+			Where("chain_id = ? AND manager = ? AND block_number < ?", cursor.ChainID, cursor.Manager, keepFrom).
+			Delete(&ManageRootBlock{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(cursor).Error
+	})
+}
+
+// rewind deletes the events and block hashes of manager above the cursor block and moves the cursor back to it
+func (r *repository) rewind(ctx context.Context, cursor *ManageRootCursor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("chain_id = ? AND manager = ? AND block_number > ?", cursor.ChainID, cursor.Manager, cursor.BlockNumber).
+			Delete(&ManageRootEvent{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.
+			Where("chain_id = ? AND manager = ? AND block_number > ?", cursor.ChainID, cursor.Manager, cursor.BlockNumber).
+			Delete(&ManageRootBlock{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(cursor).Error
+	})
+}
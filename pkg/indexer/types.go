@@ -0,0 +1,22 @@
+package indexer
+
+import "time"
+
+type Config struct {
+	// StartBlock is the first block indexed for a manager without a cursor, usually the deployment
+	// block of the oldest manager. It is required
+	StartBlock uint64
+
+	// BlockRange is the maximum number of blocks requested in one FilterLogs call
+	BlockRange uint64
+
+	// Confirmations is the number of blocks behind the head the indexer stays
+	Confirmations uint64
+
+	// ReorgDepth is the number of indexed blocks whose hashes are kept to find the common ancestor
+	// of a reorg, a deeper reorg stops the sync of the manager with ErrReorgTooDeep
+	ReorgDepth uint64
+
+	// Interval is the polling interval used by Run when the indexer is caught up
+	Interval time.Duration
+}
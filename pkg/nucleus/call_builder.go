@@ -0,0 +1,159 @@
+package nucleus
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/erc20"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/nonfungiblepositionmanager"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/swaprouter"
+)
+
+// CallBuilder builds managed calls for common DeFi actions. Every call it builds
+// declares decoderAndSanitizer as the decoder expected to sanitize it, which
+// is checked against the decoder returned with the merkle proofs
+type CallBuilder struct {
+	decoderAndSanitizer common.Address
+}
+
+func NewCallBuilder(decoderAndSanitizer common.Address) *CallBuilder {
+	return &CallBuilder{
+		decoderAndSanitizer: decoderAndSanitizer,
+	}
+}
+
+// Approve builds an ERC-20 approve(spender, amount) call on token
+func (b *CallBuilder) Approve(token common.Address, spender common.Address, amount *big.Int) (*Call, error) {
+	return b.build(erc20.ABI, token, "approve", spender, amount)
+}
+
+// MintPosition builds a NonfungiblePositionManager mint call
+func (b *CallBuilder) MintPosition(
+	positionManager common.Address,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerMintParams,
+) (*Call, error) {
+	return b.build(nonfungiblepositionmanager.ABI, positionManager, "mint", params)
+}
+
+// IncreaseLiquidity builds a NonfungiblePositionManager increaseLiquidity call
+func (b *CallBuilder) IncreaseLiquidity(
+	positionManager common.Address,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerIncreaseLiquidityParams,
+) (*Call, error) {
+	return b.build(nonfungiblepositionmanager.ABI, positionManager, "increaseLiquidity", params)
+}
+
+// DecreaseLiquidity builds a NonfungiblePositionManager decreaseLiquidity call
+func (b *CallBuilder) DecreaseLiquidity(
+	positionManager common.Address,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerDecreaseLiquidityParams,
+) (*Call, error) {
+	return b.build(nonfungiblepositionmanager.ABI, positionManager, "decreaseLiquidity", params)
+}
+
+// Collect builds a NonfungiblePositionManager collect call
+func (b *CallBuilder) Collect(
+	positionManager common.Address,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerCollectParams,
+) (*Call, error) {
+	return b.build(nonfungiblepositionmanager.ABI, positionManager, "collect", params)
+}
+
+// ExactInputSingle builds a swap router exactInputSingle call
+func (b *CallBuilder) ExactInputSingle(router common.Address, params swaprouter.ISwapRouterExactInputSingleParams) (*Call, error) {
+	return b.build(swaprouter.ABI, router, "exactInputSingle", params)
+}
+
+// ExactInput builds a swap router multi-hop exactInput call
+func (b *CallBuilder) ExactInput(router common.Address, params swaprouter.ISwapRouterExactInputParams) (*Call, error) {
+	return b.build(swaprouter.ABI, router, "exactInput", params)
+}
+
+// HyperswapMintPosition builds a mint call on the Hyperswap position manager of the network
+func (b *CallBuilder) HyperswapMintPosition(
+	network NetworkData,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerMintParams,
+) (*Call, error) {
+	positionManager, err := network.Hyperswap.PositionManagerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.MintPosition(positionManager, params)
+}
+
+// HyperswapIncreaseLiquidity builds an increaseLiquidity call on the Hyperswap position manager of the network
+func (b *CallBuilder) HyperswapIncreaseLiquidity(
+	network NetworkData,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerIncreaseLiquidityParams,
+) (*Call, error) {
+	positionManager, err := network.Hyperswap.PositionManagerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.IncreaseLiquidity(positionManager, params)
+}
+
+// HyperswapDecreaseLiquidity builds a decreaseLiquidity call on the Hyperswap position manager of the network
+func (b *CallBuilder) HyperswapDecreaseLiquidity(
+	network NetworkData,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerDecreaseLiquidityParams,
+) (*Call, error) {
+	positionManager, err := network.Hyperswap.PositionManagerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.DecreaseLiquidity(positionManager, params)
+}
+
+// HyperswapCollect builds a collect call on the Hyperswap position manager of the network
+func (b *CallBuilder) HyperswapCollect(
+	network NetworkData,
+	params nonfungiblepositionmanager.INonfungiblePositionManagerCollectParams,
+) (*Call, error) {
+	positionManager, err := network.Hyperswap.PositionManagerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Collect(positionManager, params)
+}
+
+// HyperswapExactInputSingle builds an exactInputSingle swap on the Hyperswap router of the network
+func (b *CallBuilder) HyperswapExactInputSingle(network NetworkData, params swaprouter.ISwapRouterExactInputSingleParams) (*Call, error) {
+	router, err := network.Hyperswap.RouterAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.ExactInputSingle(router, params)
+}
+
+// HyperswapExactInput builds a multi-hop exactInput swap on the Hyperswap router of the network
+func (b *CallBuilder) HyperswapExactInput(network NetworkData, params swaprouter.ISwapRouterExactInputParams) (*Call, error) {
+	router, err := network.Hyperswap.RouterAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.ExactInput(router, params)
+}
+
+func (b *CallBuilder) build(contractABI *abi.ABI, target common.Address, method string, args ...interface{}) (*Call, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Call{
+		TargetAddress:       target.Hex(),
+		Data:                data,
+		Args:                args,
+		FunctionSignature:   contractABI.Methods[method].Sig,
+		DecoderAndSanitizer: b.decoderAndSanitizer.Hex(),
+	}, nil
+}
@@ -2,22 +2,26 @@ package nucleus
 
 import (
 	"context"
-	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"math/big"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
 	"github.com/Tempest-Finance/console-strategies-common/pkg/rpcregistry"
 )
 
+// receiptTimeout is how long Execute waits for the manage transaction to be mined
+const receiptTimeout = 60 * time.Second
+
+// Call is a call to add to the queue, Value is the wei sent with it
 type Call struct {
 	TargetAddress       string        `json:"target_address"`
 	Data                []byte        `json:"data"`
@@ -32,11 +36,19 @@ type CalldataQueue struct {
 	client            IClient
 	managerAddress    common.Address
 	chainId           int64
+	symbol            string
 	strategistAddress string
 	root              string
 	calls             []Transaction
 	rpcRegistry       rpcregistry.IRegistry
 	transactor        *bind.TransactOpts
+	signer            adapter.SignerFn
+
+	mu        sync.Mutex
+	stale     bool
+	executing bool
+	txHash    string
+	pending   int
 }
 
 func NewCalldataQueue(
@@ -47,8 +59,16 @@ func NewCalldataQueue(
 	rpcRegistry rpcregistry.IRegistry,
 	transactor *bind.TransactOpts,
 ) (*CalldataQueue, error) {
-	managerAddress := common.HexToAddress(client.GetAddressBook()[chainId].Nucleus.Vaults[symbol].Manager)
+	vault, err := client.GetVault(chainId, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	managerAddress := common.HexToAddress(vault.Manager)
 	ethClient, err := rpcRegistry.GetClient(chainId)
+	if err != nil {
+		return nil, err
+	}
 
 	caller, err := manageroot.NewManageRootCaller(managerAddress, ethClient)
 	if err != nil {
@@ -60,13 +80,7 @@ func NewCalldataQueue(
 		return nil, err
 	}
 
-	rootHex := "0x" + hex.EncodeToString(root[:])
-
-	if !strings.HasPrefix(rootHex, "0x") {
-		rootHex = "0x" + rootHex
-	}
-	zeroRoot := "0x" + strings.Repeat("0", 64)
-	if rootHex == zeroRoot {
+	if root == zeroRoot {
 		return nil, ErrStrategiesIsInvalid
 	}
 
@@ -74,15 +88,48 @@ func NewCalldataQueue(
 		client:            client,
 		managerAddress:    managerAddress,
 		chainId:           chainId,
+		symbol:            symbol,
 		strategistAddress: strategistAddress,
-		root:              rootHex,
+		root:              rootToHex(root),
 		calls:             []Transaction{},
 		rpcRegistry:       rpcRegistry,
 		transactor:        transactor,
 	}, nil
 }
 
+// NewCalldataQueueWithStrategists creates a queue signing as the strategist on chainId, the remote
+// signing calls are bounded by the context given to Execute
+func NewCalldataQueueWithStrategists(
+	chainId int64,
+	strategistAddress string,
+	symbol string,
+	client IClient,
+	rpcRegistry rpcregistry.IRegistry,
+	strategists IMultiChainStrategistTransactor,
+) (*CalldataQueue, error) {
+	transactor, err := strategists.GetStrategist(chainId, strategistAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := strategists.GetSigner(chainId, strategistAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewCalldataQueue(chainId, strategistAddress, symbol, client, rpcRegistry, transactor)
+	if err != nil {
+		return nil, err
+	}
+	queue.signer = signer
+
+	return queue, nil
+}
+
 func (c *CalldataQueue) AddCall(targetAddress common.Address, calldata []byte, value *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.calls = append(c.calls, Transaction{
 		Target:    targetAddress,
 		Data:      "0x" + common.Bytes2Hex(calldata),
@@ -91,17 +138,41 @@ func (c *CalldataQueue) AddCall(targetAddress common.Address, calldata []byte, v
 	})
 }
 
+// AddCalls adds calls produced by a CallBuilder to the queue
+func (c *CalldataQueue) AddCalls(calls ...*Call) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, call := range calls {
+		c.calls = append(c.calls, callToTransaction(call))
+	}
+}
+
 func (c *CalldataQueue) GetCalldata(ctx context.Context) (*Calldata, error) {
-	batchResults, err := c.getBatchProofsAndDecoders(ctx, c.calls)
+	return c.buildCalldata(ctx, c.root, c.Calls())
+}
+
+// buildCalldata fetches the proofs of txs under root and assembles the manage call arguments
+func (c *CalldataQueue) buildCalldata(ctx context.Context, root string, txs []Transaction) (*Calldata, error) {
+	batchResults, err := c.getBatchProofsAndDecoders(ctx, root, txs)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(batchResults.ManageProofs) != len(txs) {
+		return nil, fmt.Errorf("%w: got %d proofs for %d calls", ErrUnexpectedProofs, len(batchResults.ManageProofs), len(txs))
+	}
+
+	decodersAndSanitizers := mappingDecodersAndSanitizers(batchResults.DecodersAndSanitizers)
+	if err := verifyDecodersAndSanitizers(txs, decodersAndSanitizers); err != nil {
+		return nil, err
+	}
+
 	var targets []common.Address
 	var data [][]byte
 	var values []*big.Int
 
-	for _, tx := range c.calls {
+	for _, tx := range txs {
 		targets = append(targets, tx.Target)
 		values = append(values, tx.Val)
 		data = append(data, tx.DataBytes)
@@ -109,110 +180,147 @@ func (c *CalldataQueue) GetCalldata(ctx context.Context) (*Calldata, error) {
 
 	return &Calldata{
 		ManageProofs:          mappingManageProofs(batchResults.ManageProofs),
-		DecodersAndSanitizers: mappingDecodersAndSanitizers(batchResults.DecodersAndSanitizers),
+		DecodersAndSanitizers: decodersAndSanitizers,
 		Targets:               targets,
 		TargetData:            data,
 		Values:                values,
 	}, nil
 }
 
-func (c *CalldataQueue) Execute(ctx context.Context) (string, error) {
-	client, err := c.rpcRegistry.GetClient(c.chainId)
+// Root returns the manage root the queue fetches its proofs for
+func (c *CalldataQueue) Root() string {
+	return c.root
+}
 
-	if c.calls == nil || len(c.calls) == 0 {
-		return "", ErrEmptyCalls
-	}
+// Invalidate marks the root of the queue as stale, a stale queue can no longer be executed
+func (c *CalldataQueue) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	if !strings.EqualFold(c.transactor.From.Hex(), c.strategistAddress) {
-		return "", ErrInvalidSigner
-	}
+	c.stale = true
+}
+
+func (c *CalldataQueue) IsStale() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	calldata, err := c.GetCalldata(ctx)
+	return c.stale
+}
+
+// Execute sends the queued calls in one manage transaction and waits for its receipt. The executed
+// calls are removed once it succeeds and the queue can be executed again with the calls added
+// meanwhile, a reverted transaction keeps them queued. The transaction is pending until its receipt
+// is found: Execute fails with ErrQueuePending and calls can only be appended. When the receipt is
+// not found in time it stays pending until ResolvePending finds it or DiscardPending drops it
+func (c *CalldataQueue) Execute(ctx context.Context) (string, error) {
+	calls, err := c.beginExecution()
 	if err != nil {
 		return "", err
 	}
 
-	manageRootContract, err := manageroot.NewManageRoot(c.managerAddress, client)
+	client, err := c.rpcRegistry.GetClient(c.chainId)
 	if err != nil {
+		c.releaseExecution()
 		return "", err
 	}
+	ethClient := adapter.NewFromClient(client)
 
-	tx, err := manageRootContract.ManageVaultWithMerkleVerification(
-		c.transactor,
-		calldata.ManageProofs,
-		calldata.DecodersAndSanitizers,
-		calldata.Targets,
-		calldata.TargetData,
-		calldata.Values,
-	)
+	tx, err := c.send(ctx, ethClient, calls)
 	if err != nil {
+		c.releaseExecution()
 		return "", err
 	}
 
-	txHash, err := c.waitForTransactionSuccess(ctx, tx.Hash(), c.chainId)
+	c.markSent(tx.Hash().Hex(), len(calls))
+
+	return tx.Hash().Hex(), c.settle(ctx, ethClient, tx.Hash())
+}
+
+// ResolvePending waits again for the receipt of the pending transaction, e.g. after Execute did not
+// find it in time, and settles it like Execute does. It does nothing without pending transaction
+func (c *CalldataQueue) ResolvePending(ctx context.Context) error {
+	txHash, err := c.beginResolve()
+	if err != nil || txHash == "" {
+		return err
+	}
+
+	client, err := c.rpcRegistry.GetClient(c.chainId)
 	if err != nil {
-		return "", err
+		c.releaseExecution()
+		return err
 	}
 
-	return txHash, nil
+	return c.settle(ctx, adapter.NewFromClient(client), common.HexToHash(txHash))
 }
 
-func (c *CalldataQueue) getBatchProofsAndDecoders(ctx context.Context, txs []Transaction) (*MerkleProofs, error) {
-	body, err := json.Marshal(map[string]interface{}{
-		"chain": strconv.FormatInt(c.chainId, 10),
-		"calls": txs,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+// settle waits for the receipt of the pending transaction txHash and ends the execution with it,
+// a transaction whose receipt was not found in time may still be mined and stays pending
+func (c *CalldataQueue) settle(ctx context.Context, ethClient adapter.EthClientAdapter, txHash common.Hash) error {
+	err := c.waitForTransactionSuccess(ctx, ethClient, txHash)
+	switch {
+	case err == nil:
+		c.endExecution(true)
+	case errors.Is(err, ErrFailedToExecute):
+		c.endExecution(false)
+	default:
+		c.releaseExecution()
+	}
+
+	return err
+}
+
+// send signs and sends the manage transaction of calls
+func (c *CalldataQueue) send(ctx context.Context, ethClient adapter.EthClientAdapter, calls []Transaction) (*types.Transaction, error) {
+	if len(calls) == 0 {
+		return nil, ErrEmptyCalls
+	}
+
+	if !strings.EqualFold(c.transactor.From.Hex(), c.strategistAddress) {
+		return nil, ErrInvalidSigner
 	}
-	resp, err := c.client.Post(ctx, MultiproofsUrl+c.root, body)
+
+	calldata, err := c.buildCalldata(ctx, c.root, calls)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get batch proofs and decoders: %w", err)
+		return nil, err
 	}
 
-	var response *MerkleProofs
-	jsonBytes, err := json.Marshal(resp)
+	data, err := manageroot.ABI.Pack(
+		manageMethod,
+		calldata.ManageProofs,
+		calldata.DecodersAndSanitizers,
+		calldata.Targets,
+		calldata.TargetData,
+		calldata.Values,
+	)
 	if err != nil {
-		log.Fatalf("Error marshaling input: %v", err)
+		return nil, err
 	}
 
-	if err := json.Unmarshal(jsonBytes, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if c.signer != nil {
+		return adapter.TransactWithSigner(ctx, ethClient, c.transactor, c.signer, c.managerAddress, data)
 	}
 
-	return response, nil
+	return adapter.Transact(ctx, ethClient, c.transactor, c.managerAddress, data)
 }
 
-func (c *CalldataQueue) waitForTransactionSuccess(ctx context.Context, txHash common.Hash, chainId int64) (string, error) {
-	client, err := c.rpcRegistry.GetClient(chainId)
+func (c *CalldataQueue) getBatchProofsAndDecoders(ctx context.Context, root string, txs []Transaction) (*MerkleProofs, error) {
+	response, err := c.client.GetMultiproofs(ctx, c.chainId, root, txs)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to get batch proofs and decoders: %w", err)
 	}
-	timeoutTimer := time.NewTimer(60 * time.Second)
-	defer timeoutTimer.Stop()
-
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return "", fmt.Errorf("timeout reached while waiting tx")
-		case <-ticker.C:
-			receipt, err := client.TransactionReceipt(context.Background(), txHash)
-			if err != nil {
-				log.Printf("getting transaction receipt - error: %v, sleep for 1s...\n", err)
-				time.Sleep(time.Second)
-			}
-			if receipt != nil {
-				if receipt.Status == 1 {
-					return txHash.String(), nil
-				} else {
-					return "", fmt.Errorf("transaction failed with status %d", receipt.Status)
-				}
-			}
-		case <-timeoutTimer.C:
-			return "", fmt.Errorf("timeout reached while waiting tx")
-		}
+
+	return response, nil
+}
+
+// waitForTransactionSuccess waits for the receipt of txHash, a reverted transaction fails with ErrFailedToExecute
+func (c *CalldataQueue) waitForTransactionSuccess(ctx context.Context, ethClient adapter.EthClientAdapter, txHash common.Hash) error {
+	ctx, cancel := context.WithTimeout(ctx, receiptTimeout)
+	defer cancel()
+
+	_, err := adapter.WaitForReceipt(ctx, ethClient, txHash, 0)
+	if errors.Is(err, adapter.ErrTransactionFailed) {
+		return fmt.Errorf("%w: %w", ErrFailedToExecute, err)
 	}
+
+	return err
 }
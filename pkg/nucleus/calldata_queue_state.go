@@ -0,0 +1,336 @@
+package nucleus
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/rpcregistry"
+)
+
+// calldataQueueState is the serialized form of a CalldataQueue
+type calldataQueueState struct {
+	ChainID    int64        `json:"chainId"`
+	Symbol     string       `json:"symbol"`
+	Manager    string       `json:"manager"`
+	Strategist string       `json:"strategist"`
+	Root       string       `json:"root"`
+	Calls      []queuedCall `json:"calls"`
+	TxHash     string       `json:"txHash,omitempty"`
+	Pending    int          `json:"pending,omitempty"`
+}
+
+type queuedCall struct {
+	Target              common.Address `json:"target"`
+	Value               *hexutil.Big   `json:"value"`
+	Data                hexutil.Bytes  `json:"data"`
+	FunctionSignature   string         `json:"functionSignature,omitempty"`
+	DecoderAndSanitizer common.Address `json:"decoderAndSanitizer"`
+}
+
+// Calls returns a copy of the queued calls
+func (c *CalldataQueue) Calls() []Transaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]Transaction, len(c.calls))
+	copy(calls, c.calls)
+
+	return calls
+}
+
+func (c *CalldataQueue) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.calls)
+}
+
+// Remove removes the call at index, it fails while the queue is executing or has a pending transaction
+func (c *CalldataQueue) Remove(index int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(c.calls) {
+		return fmt.Errorf("%w: %d of %d", ErrCallIndexOutOfRange, index, len(c.calls))
+	}
+
+	c.calls = append(c.calls[:index], c.calls[index+1:]...)
+
+	return nil
+}
+
+// InsertAt inserts calls before the call at index, index equal to the queue length appends them.
+// It fails while the queue is executing or has a pending transaction
+func (c *CalldataQueue) InsertAt(index int, calls ...*Call) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	if index < 0 || index > len(c.calls) {
+		return fmt.Errorf("%w: %d of %d", ErrCallIndexOutOfRange, index, len(c.calls))
+	}
+
+	txs := make([]Transaction, 0, len(c.calls)+len(calls))
+	txs = append(txs, c.calls[:index]...)
+	for _, call := range calls {
+		txs = append(txs, callToTransaction(call))
+	}
+	txs = append(txs, c.calls[index:]...)
+	c.calls = txs
+
+	return nil
+}
+
+// Reset removes all queued calls, it fails while the queue is executing or has a pending transaction
+func (c *CalldataQueue) Reset() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	c.calls = []Transaction{}
+
+	return nil
+}
+
+// TxHash returns the hash of the pending transaction of the queue, empty when none is pending
+func (c *CalldataQueue) TxHash() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.txHash
+}
+
+func (c *CalldataQueue) IsPending() bool {
+	return c.TxHash() != ""
+}
+
+// DiscardPending drops the pending transaction and keeps its calls queued, for a transaction known
+// to be dropped or replaced. Executing the queue while the transaction can still be mined may execute
+// its calls twice
+func (c *CalldataQueue) DiscardPending() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.executing {
+		return ErrQueueExecuting
+	}
+
+	c.txHash = ""
+	c.pending = 0
+
+	return nil
+}
+
+// checkMutable fails when the first calls belong to an executing or pending transaction,
+// only appends keep them in place
+func (c *CalldataQueue) checkMutable() error {
+	if c.executing {
+		return ErrQueueExecuting
+	}
+
+	if c.txHash != "" {
+		return fmt.Errorf("%w: %s", ErrQueuePending, c.txHash)
+	}
+
+	return nil
+}
+
+// beginExecution marks the queue as executing and returns the calls to execute
+func (c *CalldataQueue) beginExecution() ([]Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.txHash != "" {
+		return nil, fmt.Errorf("%w: %s", ErrQueuePending, c.txHash)
+	}
+
+	if c.executing {
+		return nil, ErrQueueExecuting
+	}
+
+	if c.stale {
+		return nil, ErrStaleManageRoot
+	}
+
+	c.executing = true
+
+	calls := make([]Transaction, len(c.calls))
+	copy(calls, c.calls)
+
+	return calls, nil
+}
+
+// beginResolve marks the queue as executing and returns the hash of its pending transaction,
+// empty when none is pending
+func (c *CalldataQueue) beginResolve() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.executing {
+		return "", ErrQueueExecuting
+	}
+
+	if c.txHash != "" {
+		c.executing = true
+	}
+
+	return c.txHash, nil
+}
+
+// markSent records the sent transaction executing the first executed calls, it is pending until it is settled
+func (c *CalldataQueue) markSent(txHash string, executed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.txHash = txHash
+	c.pending = executed
+}
+
+// releaseExecution ends an execution whose transaction was not sent or not settled, a sent one stays pending
+func (c *CalldataQueue) releaseExecution() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.executing = false
+}
+
+// endExecution settles the pending transaction, on success its calls are removed. They are
+// the first calls of the queue since only appends are allowed while it is pending
+func (c *CalldataQueue) endExecution(succeeded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.executing = false
+	if succeeded {
+		c.calls = append([]Transaction{}, c.calls[c.pending:]...)
+	}
+	c.txHash = ""
+	c.pending = 0
+}
+
+// MarshalJSON serializes the queued calls with the manager, strategist and root they were built for,
+// the call arguments are not kept and are decoded again from the calldata when described
+func (c *CalldataQueue) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := calldataQueueState{
+		ChainID:    c.chainId,
+		Symbol:     c.symbol,
+		Manager:    c.managerAddress.Hex(),
+		Strategist: c.strategistAddress,
+		Root:       c.root,
+		Calls:      make([]queuedCall, 0, len(c.calls)),
+		TxHash:     c.txHash,
+		Pending:    c.pending,
+	}
+
+	for _, tx := range c.calls {
+		value := tx.Val
+		if value == nil {
+			value = big.NewInt(0)
+		}
+
+		state.Calls = append(state.Calls, queuedCall{
+			Target:              tx.Target,
+			Value:               (*hexutil.Big)(value),
+			Data:                tx.DataBytes,
+			FunctionSignature:   tx.FunctionSignature,
+			DecoderAndSanitizer: tx.DecoderAndSanitizer,
+		})
+	}
+
+	return json.Marshal(state)
+}
+
+// RestoreCalldataQueue restores a queue serialized with MarshalJSON, e.g. to execute it in a worker.
+// The manager must still be the manager of the vault in the address book and the queue is restored
+// as stale when the manage root changed since it was serialized.
+// The pending transaction guard only covers the restored copy and the hash it was serialized with:
+// callers restoring the same state in several workers must hold an external lock across restore and
+// Execute, and store the queue again after Execute so a pending TxHash is persisted
+func RestoreCalldataQueue(
+	data []byte,
+	client IClient,
+	rpcRegistry rpcregistry.IRegistry,
+	transactor *bind.TransactOpts,
+) (*CalldataQueue, error) {
+	var state calldataQueueState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	vault, err := client.GetVault(state.ChainID, state.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(vault.Manager, state.Manager) {
+		return nil, fmt.Errorf("%w: queue of %s on chainID %d has manager %s, address book has %s",
+			ErrManagerMismatch, state.Symbol, state.ChainID, state.Manager, vault.Manager)
+	}
+
+	managerAddress := common.HexToAddress(state.Manager)
+	ethClient, err := rpcRegistry.GetClient(state.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := manageroot.NewManageRootCaller(managerAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := caller.ManageRoot(nil, common.HexToAddress(state.Strategist))
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Pending < 0 || state.Pending > len(state.Calls) {
+		return nil, fmt.Errorf("%w: %d pending calls of %d", ErrCallIndexOutOfRange, state.Pending, len(state.Calls))
+	}
+
+	calls := make([]Transaction, 0, len(state.Calls))
+	for _, call := range state.Calls {
+		calls = append(calls, Transaction{
+			Target:              call.Target,
+			Data:                hexutil.Encode(call.Data),
+			Val:                 call.Value.ToInt(),
+			DataBytes:           call.Data,
+			FunctionSignature:   call.FunctionSignature,
+			DecoderAndSanitizer: call.DecoderAndSanitizer,
+		})
+	}
+
+	return &CalldataQueue{
+		client:            client,
+		managerAddress:    managerAddress,
+		chainId:           state.ChainID,
+		symbol:            state.Symbol,
+		strategistAddress: state.Strategist,
+		root:              state.Root,
+		calls:             calls,
+		rpcRegistry:       rpcRegistry,
+		transactor:        transactor,
+		stale:             !strings.EqualFold(rootToHex(root), state.Root),
+		txHash:            state.TxHash,
+		pending:           state.Pending,
+	}, nil
+}
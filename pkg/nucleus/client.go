@@ -2,10 +2,16 @@ package nucleus
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/http"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
 )
 
 type Client struct {
@@ -14,7 +20,10 @@ type Client struct {
 
 	nucleusAPIKey string
 	baseURL       string
-	addressBook   map[int64]NetworkData
+
+	mu                  sync.RWMutex
+	addressBook         map[int64]NetworkData
+	vaultChangeHandlers []VaultChangeHandler
 }
 
 func NewClient(nucleusAPIKey, baseURL string) (*Client, error) {
@@ -26,62 +35,111 @@ func NewClient(nucleusAPIKey, baseURL string) (*Client, error) {
 		SetHeader("Content-Type", "application/json").
 		SetHeader("x-api-key", nucleusAPIKey)
 
-	resp, err := client.R().Get(AddressBookUrl)
-	if err != nil {
-		return nil, err
-	}
-
-	if !resp.IsSuccess() {
-		return nil, errors.New(fmt.Sprintf("Failed to fetch address book: %s", resp.Status()))
+	c := &Client{
+		client:        client,
+		nucleusAPIKey: nucleusAPIKey,
+		baseURL:       baseURL,
 	}
 
-	addressBook, err := parseAddressBook(resp.Body())
+	addressBook, err := c.fetchAddressBook(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	c.addressBook = addressBook
 
-	return &Client{
-		client:        client,
-		nucleusAPIKey: nucleusAPIKey,
-		baseURL:       baseURL,
-		addressBook:   addressBook,
-	}, nil
+	return c, nil
 }
 
-func (c *Client) Get(ctx context.Context, endpoint string, queryParams map[string]string) (interface{}, error) {
-	return c.request(ctx, "GET", endpoint, nil, queryParams)
-}
-
-func (c *Client) Post(ctx context.Context, endpoint string, jsonData []byte) (interface{}, error) {
-	return c.request(ctx, "POST", endpoint, jsonData, nil)
+// GetMultiproofs returns the manage proofs and decoders for txs under the given manage root
+func (c *Client) GetMultiproofs(ctx context.Context, chainID int64, root string, txs []Transaction) (*MerkleProofs, error) {
+	return Post[MerkleProofs](ctx, c, MultiproofsUrl+root, &MultiproofsRequest{
+		Chain: strconv.FormatInt(chainID, 10),
+		Calls: txs,
+	})
 }
 
 func (c *Client) GetAddressBook() map[int64]NetworkData {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.addressBook
 }
 
-func (c *Client) request(ctx context.Context, method, endpoint string, jsonData []byte, queryParams map[string]string) (interface{}, error) {
-	var result interface{}
-	req := c.client.R().
-		SetContext(ctx).
-		SetResult(&result)
+// GetVault returns the vault registered under symbol on chainID in the current address book
+func (c *Client) GetVault(chainID int64, symbol string) (VaultDetail, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if jsonData != nil {
-		req.SetBody(jsonData)
+	network, ok := c.addressBook[chainID]
+	if !ok {
+		return VaultDetail{}, fmt.Errorf("%w: chainID %d", ErrChainNotFound, chainID)
 	}
 
-	if len(queryParams) > 0 {
-		req.SetQueryParams(queryParams)
+	vault, ok := network.Nucleus.Vaults[symbol]
+	if !ok {
+		return VaultDetail{}, fmt.Errorf("%w: symbol %s on chainID %d", ErrVaultNotFound, symbol, chainID)
 	}
 
-	resp, err := req.Execute(method, endpoint)
+	return vault, nil
+}
+
+// OnVaultChange registers a handler that is called when a refresh detects
+// a new manager or teller address for an existing vault
+func (c *Client) OnVaultChange(handler VaultChangeHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.vaultChangeHandlers = append(c.vaultChangeHandlers, handler)
+}
+
+// RefreshAddressBook fetches the address book again, replaces the cached one
+// and notifies the registered handlers about changed vaults
+func (c *Client) RefreshAddressBook(ctx context.Context) error {
+	addressBook, err := c.fetchAddressBook(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if !resp.IsSuccess() {
-		return nil, ErrFailedToExecute
+	c.mu.Lock()
+	changes := diffVaults(c.addressBook, addressBook)
+	c.addressBook = addressBook
+	handlers := c.vaultChangeHandlers
+	c.mu.Unlock()
+
+	for _, change := range changes {
+		for _, handler := range handlers {
+			handler(change)
+		}
+	}
+
+	return nil
+}
+
+// StartAddressBookRefresher refreshes the address book every interval until ctx is done
+func (c *Client) StartAddressBookRefresher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.RefreshAddressBook(ctx); err != nil {
+					logger.Warnf(ctx, "[Nucleus Client] Refresh address book error: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+// fetchAddressBook gets the address book without logging its body, which would be logged on every refresh
+func (c *Client) fetchAddressBook(ctx context.Context) (AddressBook, error) {
+	resp, err := execute(http.R[json.RawMessage, string](c.client).SetLogReqRes(false).Get(ctx, AddressBookUrl))
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return parseAddressBook(*resp)
 }
@@ -5,8 +5,24 @@ import (
 )
 
 var (
-	ErrFailedToExecute     = errors.New("failed to execute")
-	ErrStrategiesIsInvalid = errors.New("strategies is invalid")
-	ErrInvalidSigner       = errors.New("invalid signer")
-	ErrEmptyCalls          = errors.New("empty calls")
+	ErrFailedToExecute         = errors.New("failed to execute")
+	ErrStrategiesIsInvalid     = errors.New("strategies is invalid")
+	ErrInvalidSigner           = errors.New("invalid signer")
+	ErrEmptyCalls              = errors.New("empty calls")
+	ErrChainNotFound           = errors.New("chain not found in address book")
+	ErrVaultNotFound           = errors.New("vault not found in address book")
+	ErrReadVaultStateFailed    = errors.New("failed to read vault state")
+	ErrUnexpectedDecoder       = errors.New("unexpected decoder and sanitizer")
+	ErrUnexpectedProofs        = errors.New("unexpected manage proofs")
+	ErrUnknownKeySource        = errors.New("unknown key source")
+	ErrKeySourceMismatch       = errors.New("key source address does not match strategist address")
+	ErrRemoteSignFailed        = errors.New("remote signer failed to sign transaction")
+	ErrStrategistNotFound      = errors.New("strategist not found")
+	ErrStaleManageRoot         = errors.New("manage root changed since the queue was created")
+	ErrInvalidFlashLoan        = errors.New("invalid flash loan")
+	ErrCallIndexOutOfRange     = errors.New("call index out of range")
+	ErrQueueExecuting          = errors.New("queue is being executed")
+	ErrQueuePending            = errors.New("queue has a pending transaction")
+	ErrManagerMismatch         = errors.New("manager does not match the vault in the address book")
+	ErrInvalidAddressBookEntry = errors.New("invalid address book entry")
 )
@@ -0,0 +1,231 @@
+package nucleus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/erc20"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/nonfungiblepositionmanager"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/swaprouter"
+)
+
+const manageMethod = "manageVaultWithMerkleVerification"
+
+// knownABIs are used to decode hand-packed calls that carry no function signature
+var knownABIs = []*abi.ABI{
+	erc20.ABI,
+	nonfungiblepositionmanager.ABI,
+	swaprouter.ABI,
+	manageroot.ABI,
+}
+
+// BatchDescription describes a batch of managed calls for review before it is sent
+type BatchDescription struct {
+	ChainID    int64             `json:"chainId"`
+	Manager    common.Address    `json:"manager"`
+	Strategist common.Address    `json:"strategist"`
+	Root       string            `json:"root"`
+	Calls      []CallDescription `json:"calls"`
+}
+
+type CallDescription struct {
+	Index               int            `json:"index"`
+	Target              common.Address `json:"target"`
+	Value               string         `json:"value"`
+	FunctionSignature   string         `json:"functionSignature"`
+	Args                []string       `json:"args"`
+	Data                string         `json:"data"`
+	DecoderAndSanitizer common.Address `json:"decoderAndSanitizer"`
+	Proof               []string       `json:"proof"`
+}
+
+// UnsignedTransaction is the manage transaction of a batch left for a multisig to sign and send
+type UnsignedTransaction struct {
+	ChainID     int64             `json:"chainId"`
+	From        common.Address    `json:"from"`
+	To          common.Address    `json:"to"`
+	Value       string            `json:"value"`
+	Data        string            `json:"data"`
+	Description *BatchDescription `json:"description"`
+}
+
+// Describe fetches the proofs of the queued calls and describes the batch without sending it
+func (c *CalldataQueue) Describe(ctx context.Context) (*BatchDescription, error) {
+	calls := c.Calls()
+	if len(calls) == 0 {
+		return nil, ErrEmptyCalls
+	}
+
+	calldata, err := c.buildCalldata(ctx, c.root, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.describe(calls, calldata), nil
+}
+
+// BuildUnsignedTransaction builds the manage transaction of the queued calls without signing it,
+// the strategist is expected to be a multisig that signs and sends it on its own
+func (c *CalldataQueue) BuildUnsignedTransaction(ctx context.Context) (*UnsignedTransaction, error) {
+	if c.IsStale() {
+		return nil, ErrStaleManageRoot
+	}
+
+	calls := c.Calls()
+	if len(calls) == 0 {
+		return nil, ErrEmptyCalls
+	}
+
+	calldata, err := c.buildCalldata(ctx, c.root, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := manageroot.ABI.Pack(
+		manageMethod,
+		calldata.ManageProofs,
+		calldata.DecodersAndSanitizers,
+		calldata.Targets,
+		calldata.TargetData,
+		calldata.Values,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnsignedTransaction{
+		ChainID:     c.chainId,
+		From:        common.HexToAddress(c.strategistAddress),
+		To:          c.managerAddress,
+		Value:       "0",
+		Data:        hexutil.Encode(data),
+		Description: c.describe(calls, calldata),
+	}, nil
+}
+
+func (c *CalldataQueue) describe(calls []Transaction, calldata *Calldata) *BatchDescription {
+	description := &BatchDescription{
+		ChainID:    c.chainId,
+		Manager:    c.managerAddress,
+		Strategist: common.HexToAddress(c.strategistAddress),
+		Root:       c.root,
+		Calls:      make([]CallDescription, 0, len(calls)),
+	}
+
+	for i, tx := range calls {
+		signature, args := decodeTransaction(tx)
+
+		value := "0"
+		if tx.Val != nil {
+			value = tx.Val.String()
+		}
+
+		proof := make([]string, 0, len(calldata.ManageProofs[i]))
+		for _, p := range calldata.ManageProofs[i] {
+			proof = append(proof, hexutil.Encode(p[:]))
+		}
+
+		description.Calls = append(description.Calls, CallDescription{
+			Index:               i,
+			Target:              tx.Target,
+			Value:               value,
+			FunctionSignature:   signature,
+			Args:                args,
+			Data:                hexutil.Encode(tx.DataBytes),
+			DecoderAndSanitizer: calldata.DecodersAndSanitizers[i],
+			Proof:               proof,
+		})
+	}
+
+	return description
+}
+
+// JSON returns the indented JSON description of the batch
+func (d *BatchDescription) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// String returns a human-readable description of the batch
+func (d *BatchDescription) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Manager %s on chainID %d\n", d.Manager.Hex(), d.ChainID)
+	fmt.Fprintf(&b, "Strategist %s, root %s\n", d.Strategist.Hex(), d.Root)
+	fmt.Fprintf(&b, "%d call(s):\n", len(d.Calls))
+
+	for _, call := range d.Calls {
+		fmt.Fprintf(&b, "  [%d] %s.%s\n", call.Index, call.Target.Hex(), call.FunctionSignature)
+		for i, arg := range call.Args {
+			fmt.Fprintf(&b, "      arg %d: %s\n", i, arg)
+		}
+		fmt.Fprintf(&b, "      value: %s\n", call.Value)
+		fmt.Fprintf(&b, "      decoder: %s\n", call.DecoderAndSanitizer.Hex())
+		fmt.Fprintf(&b, "      proof: %d node(s)\n", len(call.Proof))
+	}
+
+	return b.String()
+}
+
+// decodeTransaction returns the signature and formatted arguments of tx, falling back to the known
+// ABIs by selector for hand-packed or restored calls and to the declared signature or raw selector otherwise
+func decodeTransaction(tx Transaction) (string, []string) {
+	if tx.FunctionSignature != "" && tx.Args != nil {
+		return tx.FunctionSignature, formatArgs(tx.Args)
+	}
+
+	if len(tx.DataBytes) < 4 {
+		return tx.FunctionSignature, nil
+	}
+
+	for _, contractABI := range knownABIs {
+		method, err := contractABI.MethodById(tx.DataBytes[:4])
+		if err != nil {
+			continue
+		}
+
+		args, err := method.Inputs.Unpack(tx.DataBytes[4:])
+		if err != nil {
+			continue
+		}
+
+		return method.Sig, formatArgs(args)
+	}
+
+	if tx.FunctionSignature != "" {
+		return tx.FunctionSignature, nil
+	}
+
+	return hexutil.Encode(tx.DataBytes[:4]), nil
+}
+
+func formatArgs(args []interface{}) []string {
+	formatted := make([]string, 0, len(args))
+	for _, arg := range args {
+		formatted = append(formatted, formatArg(arg))
+	}
+
+	return formatted
+}
+
+func formatArg(arg interface{}) string {
+	switch v := arg.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
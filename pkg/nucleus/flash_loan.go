@@ -0,0 +1,164 @@
+package nucleus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abitypes"
+)
+
+var bytes32ArrArr, _ = abi.NewType("bytes32[][]", "", nil)
+
+// flashLoanUserData is the layout the manager decodes in receiveFlashLoan
+// to run the inner batch of managed calls
+var flashLoanUserData = abi.Arguments{
+	{Type: bytes32ArrArr},
+	{Type: abitypes.AddressArr},
+	{Type: abitypes.AddressArr},
+	{Type: abitypes.BytesArr},
+	{Type: abitypes.Uint256Arr},
+}
+
+// FlashLoan is a Balancer flash loan taken by the manager, the inner calls
+// are executed by the manager while the loaned tokens are held by the vault
+type FlashLoan struct {
+	Tokens  []common.Address
+	Amounts []*big.Int
+
+	calls []Transaction
+}
+
+func NewFlashLoan(tokens []common.Address, amounts []*big.Int) *FlashLoan {
+	return &FlashLoan{
+		Tokens:  tokens,
+		Amounts: amounts,
+	}
+}
+
+// AddCall adds a hand-packed call to the inner batch
+func (f *FlashLoan) AddCall(targetAddress common.Address, calldata []byte, value *big.Int) {
+	f.calls = append(f.calls, Transaction{
+		Target:    targetAddress,
+		Data:      "0x" + common.Bytes2Hex(calldata),
+		Val:       value,
+		DataBytes: calldata,
+	})
+}
+
+// AddCalls adds calls produced by a CallBuilder to the inner batch
+func (f *FlashLoan) AddCalls(calls ...*Call) {
+	for _, call := range calls {
+		f.calls = append(f.calls, callToTransaction(call))
+	}
+}
+
+// validate checks the loan like the Balancer vault does, which reverts unless the tokens are
+// sorted ascending without duplicates and every token has an amount
+func (f *FlashLoan) validate() error {
+	if len(f.Tokens) == 0 {
+		return fmt.Errorf("%w: no tokens", ErrInvalidFlashLoan)
+	}
+
+	if len(f.Tokens) != len(f.Amounts) {
+		return fmt.Errorf("%w: %d tokens, %d amounts", ErrInvalidFlashLoan, len(f.Tokens), len(f.Amounts))
+	}
+
+	for i, token := range f.Tokens {
+		if i > 0 && bytes.Compare(f.Tokens[i-1].Bytes(), token.Bytes()) >= 0 {
+			return fmt.Errorf("%w: tokens must be sorted ascending and unique, %s follows %s",
+				ErrInvalidFlashLoan, token.Hex(), f.Tokens[i-1].Hex())
+		}
+
+		if f.Amounts[i] == nil || f.Amounts[i].Sign() < 0 {
+			return fmt.Errorf("%w: invalid amount %v for token %s", ErrInvalidFlashLoan, f.Amounts[i], token.Hex())
+		}
+	}
+
+	return nil
+}
+
+// AddFlashLoan fetches the proofs of the inner calls, which are verified against the
+// manage root of the manager itself, nests them into the flash loan user data and
+// adds the flashLoan call to the queue so both batches execute in one transaction
+func (c *CalldataQueue) AddFlashLoan(ctx context.Context, flashLoan *FlashLoan) error {
+	if len(flashLoan.calls) == 0 {
+		return ErrEmptyCalls
+	}
+
+	if err := flashLoan.validate(); err != nil {
+		return err
+	}
+
+	client, err := c.rpcRegistry.GetClient(c.chainId)
+	if err != nil {
+		return err
+	}
+
+	caller, err := manageroot.NewManageRootCaller(c.managerAddress, client)
+	if err != nil {
+		return err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	balancerVault, err := caller.BalancerVault(callOpts)
+	if err != nil {
+		return err
+	}
+
+	if balancerVault == (common.Address{}) {
+		return fmt.Errorf("%w: no balancer vault set on manager %s", ErrInvalidFlashLoan, c.managerAddress.Hex())
+	}
+
+	innerRoot, err := caller.ManageRoot(callOpts, c.managerAddress)
+	if err != nil {
+		return err
+	}
+
+	if innerRoot == zeroRoot {
+		return fmt.Errorf("%w: no manage root set for manager %s", ErrInvalidFlashLoan, c.managerAddress.Hex())
+	}
+
+	inner, err := c.buildCalldata(ctx, rootToHex(innerRoot), flashLoan.calls)
+	if err != nil {
+		return err
+	}
+
+	userData, err := flashLoanUserData.Pack(
+		inner.ManageProofs,
+		inner.DecodersAndSanitizers,
+		inner.Targets,
+		inner.TargetData,
+		inner.Values,
+	)
+	if err != nil {
+		return err
+	}
+
+	args := []interface{}{c.managerAddress, flashLoan.Tokens, flashLoan.Amounts, userData}
+	data, err := manageroot.ABI.Pack("flashLoan", args...)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, Transaction{
+		Target:            c.managerAddress,
+		Data:              "0x" + common.Bytes2Hex(data),
+		Val:               big.NewInt(0),
+		DataBytes:         data,
+		FunctionSignature: manageroot.ABI.Methods["flashLoan"].Sig,
+		Args:              args,
+	})
+
+	return nil
+}
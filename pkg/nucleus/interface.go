@@ -8,13 +8,26 @@ import (
 )
 
 type IClient interface {
-	Get(ctx context.Context, endpoint string, queryParams map[string]string) (interface{}, error)
-	Post(ctx context.Context, endpoint string, jsonData []byte) (interface{}, error)
+	GetMultiproofs(ctx context.Context, chainID int64, root string, txs []Transaction) (*MerkleProofs, error)
 	GetAddressBook() map[int64]NetworkData
+	GetVault(chainID int64, symbol string) (VaultDetail, error)
 }
 
 type ICalldataQueue interface {
 	AddCall(targetAddress common.Address, calldata []byte, value *big.Int)
+	AddCalls(calls ...*Call)
+	AddFlashLoan(ctx context.Context, flashLoan *FlashLoan) error
 	GetCalldata(ctx context.Context) (*Calldata, error)
+	Describe(ctx context.Context) (*BatchDescription, error)
+	BuildUnsignedTransaction(ctx context.Context) (*UnsignedTransaction, error)
 	Execute(ctx context.Context) (string, error)
+	Calls() []Transaction
+	Remove(index int) error
+	InsertAt(index int, calls ...*Call) error
+	Reset() error
+}
+
+type IVaultReader interface {
+	GetVaultState(ctx context.Context, chainID int64, symbol string, assets []common.Address) (*VaultState, error)
+	GetVaultStates(ctx context.Context, chainID int64, symbols []string, assets []common.Address) (map[string]*VaultState, error)
 }
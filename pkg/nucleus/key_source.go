@@ -0,0 +1,201 @@
+package nucleus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	KeySourceEnv      = "env"
+	KeySourceKeystore = "keystore"
+	KeySourceRemote   = "remote"
+
+	remoteSignMethod  = "eth_signTransaction"
+	remoteSignTimeout = 30 * time.Second
+)
+
+// IKeySource produces transaction options that sign with a strategist key
+type IKeySource interface {
+	Address() common.Address
+	TransactOpts(chainID *big.Int) (*bind.TransactOpts, error)
+	// SignTx signs tx for chainID, ctx bounds signing with a remote signer
+	SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// NewKeySource creates the key source described by config, an empty Source defaults to KeySourceEnv
+func NewKeySource(config StrategistSignerConfig) (IKeySource, error) {
+	switch config.Source {
+	case "", KeySourceEnv:
+		return NewEnvKeySource(config.Name)
+	case KeySourceKeystore:
+		return NewKeystoreKeySource(config.KeystorePath, os.Getenv(config.Name))
+	case KeySourceRemote:
+		return NewRemoteKeySource(config.RemoteURL, common.HexToAddress(config.Address))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeySource, config.Source)
+	}
+}
+
+// PrivateKeySource signs with a private key held in memory
+type PrivateKeySource struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewEnvKeySource reads a hex encoded private key from the environment variable name
+func NewEnvKeySource(name string) (*PrivateKeySource, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(os.Getenv(name), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", name, err)
+	}
+
+	return &PrivateKeySource{privateKey: privateKey}, nil
+}
+
+// NewKeystoreKeySource decrypts the private key stored in an encrypted keystore file
+func NewKeystoreKeySource(path string, passphrase string) (*PrivateKeySource, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", path, err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+
+	return &PrivateKeySource{privateKey: key.PrivateKey}, nil
+}
+
+func (s *PrivateKeySource) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+func (s *PrivateKeySource) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(s.privateKey, chainID)
+}
+
+func (s *PrivateKeySource) SignTx(_ context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
+
+// RemoteKeySource signs transactions with a remote signer speaking JSON-RPC eth_signTransaction,
+// such as web3signer
+type RemoteKeySource struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+func NewRemoteKeySource(url string, address common.Address) (*RemoteKeySource, error) {
+	client, err := rpc.DialHTTP(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer %s: %w", url, err)
+	}
+
+	return &RemoteKeySource{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+func (s *RemoteKeySource) Address() common.Address {
+	return s.address
+}
+
+// TransactOpts returns options whose signer calls the remote signer bounded only by remoteSignTimeout,
+// since bind signers take no context. SignTx bounds the call with the caller's context
+func (s *RemoteKeySource) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+
+	return &bind.TransactOpts{
+		From: s.address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			return s.SignTx(context.Background(), chainID, tx)
+		},
+	}, nil
+}
+
+// SignTx signs tx with the remote signer, the call is bounded by ctx and remoteSignTimeout
+func (s *RemoteKeySource) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	signer := types.LatestSignerForChainID(chainID)
+
+	ctx, cancel := context.WithTimeout(ctx, remoteSignTimeout)
+	defer cancel()
+
+	var raw hexutil.Bytes
+	if err := s.client.CallContext(ctx, &raw, remoteSignMethod, toSendTxArgs(s.address, chainID, tx)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRemoteSignFailed, err)
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRemoteSignFailed, err)
+	}
+
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRemoteSignFailed, err)
+	}
+
+	if sender != s.address {
+		return nil, fmt.Errorf("%w: signed by %s", ErrRemoteSignFailed, sender.Hex())
+	}
+
+	return signedTx, nil
+}
+
+func toSendTxArgs(from common.Address, chainID *big.Int, tx *types.Transaction) apitypes.SendTxArgs {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(from),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	if accessList := tx.AccessList(); len(accessList) > 0 {
+		args.AccessList = &accessList
+	}
+
+	return args
+}
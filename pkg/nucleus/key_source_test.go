@@ -0,0 +1,78 @@
+package nucleus
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var chainID = big.NewInt(1)
+
+func newTx() *types.Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(0),
+	})
+}
+
+func TestPrivateKeySourceSignTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	source := &PrivateKeySource{privateKey: key}
+
+	signed, err := source.SignTx(context.Background(), chainID, newTx())
+	if err != nil {
+		t.Fatalf("SignTx: %v", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil || sender != source.Address() {
+		t.Fatalf("sender = %s, %v, want %s", sender, err, source.Address())
+	}
+}
+
+func TestRemoteKeySourceSignTxContext(t *testing.T) {
+	// the remote signer does not answer before the test ends
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	source, err := NewRemoteKeySource(server.URL, common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatalf("NewRemoteKeySource: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = source.SignTx(ctx, chainID, newTx())
+	if !errors.Is(err, ErrRemoteSignFailed) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SignTx error = %v, want %v caused by the deadline", err, ErrRemoteSignFailed)
+	}
+	if elapsed := time.Since(start); elapsed > remoteSignTimeout/2 {
+		t.Fatalf("SignTx returned after %s, the caller's context was not used", elapsed)
+	}
+}
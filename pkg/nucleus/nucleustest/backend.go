@@ -0,0 +1,139 @@
+package nucleustest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus"
+)
+
+const (
+	// ChainID is the chain id of the simulated backend
+	ChainID = adaptertest.ChainID
+)
+
+var (
+	// ManagerAddress is the address the ManageRoot mock is deployed at
+	ManagerAddress = common.HexToAddress("0x000000000000000000000000000000000000a11c")
+
+	strategistBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+)
+
+// Registry is an rpcregistry.IRegistry serving the simulated chain
+type Registry = adaptertest.Registry
+
+// Backend is a simulated chain with the ManageRoot mock deployed at ManagerAddress and a funded strategist,
+// its rpc client serves the Multicall3 at adaptertest.MulticallAddress
+type Backend struct {
+	*adaptertest.Simulated
+
+	strategistKey *ecdsa.PrivateKey
+}
+
+// NewBackend starts a simulated chain, the roots of the given strategists are set in genesis
+func NewBackend(roots map[common.Address][32]byte) (*Backend, error) {
+	strategistKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	storage := make(map[common.Hash]common.Hash, len(roots))
+	for strategist, root := range roots {
+		storage[ManageRootSlot(strategist)] = root
+	}
+
+	sim, err := adaptertest.NewSimulated(types.GenesisAlloc{
+		crypto.PubkeyToAddress(strategistKey.PublicKey): {Balance: strategistBalance},
+		ManagerAddress: {Code: ManageRootCode(), Storage: storage},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backend{
+		Simulated:     sim,
+		strategistKey: strategistKey,
+	}, nil
+}
+
+// Strategist returns the address of the funded strategist
+func (b *Backend) Strategist() common.Address {
+	return crypto.PubkeyToAddress(b.strategistKey.PublicKey)
+}
+
+// Transactor returns transaction options signing with the strategist key
+func (b *Backend) Transactor() (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(b.strategistKey, big.NewInt(ChainID))
+}
+
+// SetManageRoot sets the root of strategist on the ManageRoot mock and mines the transaction
+func (b *Backend) SetManageRoot(ctx context.Context, strategist common.Address, root [32]byte) error {
+	transactor, err := b.Transactor()
+	if err != nil {
+		return err
+	}
+	transactor.Context = ctx
+
+	contract, err := manageroot.NewManageRootTransactor(ManagerAddress, b.EthClient())
+	if err != nil {
+		return err
+	}
+
+	tx, err := contract.SetManageRoot(transactor, strategist, root)
+	if err != nil {
+		return err
+	}
+	b.Commit()
+
+	receipt, err := b.EthClient().TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("setManageRoot transaction %s reverted", tx.Hash().Hex())
+	}
+
+	return nil
+}
+
+// AutoCommit mines a block every interval until ctx is done, so calls waiting
+// for a receipt such as CalldataQueue.Execute can complete
+func (b *Backend) AutoCommit(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				b.Commit()
+			}
+		}
+	}()
+}
+
+// NewCalldataQueue adds the symbol vault managed by the mock to client and creates a queue
+// signing with the strategist
+func (b *Backend) NewCalldataQueue(client *Client, symbol string) (*nucleus.CalldataQueue, error) {
+	transactor, err := b.Transactor()
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetVault(ChainID, symbol, nucleus.VaultDetail{Manager: ManagerAddress.Hex()})
+
+	return nucleus.NewCalldataQueue(ChainID, b.Strategist().Hex(), symbol, client, b.Registry(), transactor)
+}
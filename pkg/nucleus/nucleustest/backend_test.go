@@ -0,0 +1,196 @@
+package nucleustest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	callermanageroot "github.com/Tempest-Finance/console-strategies-common/pkg/caller/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus/nucleustest"
+)
+
+const symbol = "TEST"
+
+var (
+	root     = [32]byte{1}
+	newRoot  = [32]byte{2}
+	target   = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	calldata = []byte{0xde, 0xad, 0xbe, 0xef}
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func newBackend(t *testing.T) (*nucleustest.Backend, context.Context) {
+	t.Helper()
+
+	backend, err := nucleustest.NewBackend(nil)
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+
+	if err := backend.SetManageRoot(ctx, backend.Strategist(), root); err != nil {
+		t.Fatalf("SetManageRoot: %v", err)
+	}
+
+	return backend, ctx
+}
+
+func TestCalldataQueueExecute(t *testing.T) {
+	backend, ctx := newBackend(t)
+	client := nucleustest.NewClient(nil)
+
+	queue, err := backend.NewCalldataQueue(client, symbol)
+	if err != nil {
+		t.Fatalf("NewCalldataQueue: %v", err)
+	}
+	queue.AddCall(target, calldata, big.NewInt(0))
+
+	backend.AutoCommit(ctx, 50*time.Millisecond)
+
+	txHash, err := queue.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	receipt, err := backend.EthClient().TransactionReceipt(ctx, common.HexToHash(txHash))
+	if err != nil {
+		t.Fatalf("TransactionReceipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("receipt status = %d, want %d", receipt.Status, types.ReceiptStatusSuccessful)
+	}
+	if len(receipt.Logs) != 1 || receipt.Logs[0].Topics[0] != manageroot.ABI.Events["BoringVaultManaged"].ID {
+		t.Fatalf("receipt logs = %v, want one BoringVaultManaged", receipt.Logs)
+	}
+
+	requests := client.MultiproofsRequests()
+	if len(requests) != 1 || len(requests[0].Txs) != 1 || requests[0].Txs[0].Target != target {
+		t.Fatalf("multiproofs requests = %+v, want one request for the queued call", requests)
+	}
+
+	if queue.Len() != 0 || queue.IsPending() {
+		t.Fatalf("queue after execute: len %d, hash %s, want 0 calls and no pending transaction", queue.Len(), queue.TxHash())
+	}
+
+	// the queue is reused for the next calls
+	queue.AddCall(target, calldata, big.NewInt(0))
+	next, err := queue.Execute(ctx)
+	if err != nil || next == txHash {
+		t.Fatalf("second Execute = %s, %v, want another transaction", next, err)
+	}
+}
+
+func TestCalldataQueueResolvePending(t *testing.T) {
+	backend, ctx := newBackend(t)
+
+	queue, err := backend.NewCalldataQueue(nucleustest.NewClient(nil), symbol)
+	if err != nil {
+		t.Fatalf("NewCalldataQueue: %v", err)
+	}
+	queue.AddCall(target, calldata, big.NewInt(0))
+
+	// nothing is mined, the receipt is not found in time
+	executeCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	txHash, err := queue.Execute(executeCtx)
+	if !errors.Is(err, context.DeadlineExceeded) || queue.TxHash() != txHash {
+		t.Fatalf("Execute = %s, %v, want the pending transaction and %v", txHash, err, context.DeadlineExceeded)
+	}
+
+	if _, err := queue.Execute(ctx); !errors.Is(err, nucleus.ErrQueuePending) {
+		t.Fatalf("Execute of a pending queue error = %v, want %v", err, nucleus.ErrQueuePending)
+	}
+	if err := queue.Reset(); !errors.Is(err, nucleus.ErrQueuePending) {
+		t.Fatalf("Reset of a pending queue error = %v, want %v", err, nucleus.ErrQueuePending)
+	}
+	queue.AddCall(target, calldata, big.NewInt(0))
+
+	backend.Commit()
+
+	if err := queue.ResolvePending(ctx); err != nil {
+		t.Fatalf("ResolvePending: %v", err)
+	}
+	if queue.Len() != 1 || queue.IsPending() {
+		t.Fatalf("queue after resolve: len %d, hash %s, want the appended call and no pending transaction", queue.Len(), queue.TxHash())
+	}
+}
+
+func TestCalldataQueueWithoutRoot(t *testing.T) {
+	backend, err := nucleustest.NewBackend(nil)
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+
+	_, err = backend.NewCalldataQueue(nucleustest.NewClient(nil), symbol)
+	if !errors.Is(err, nucleus.ErrStrategiesIsInvalid) {
+		t.Fatalf("NewCalldataQueue error = %v, want %v", err, nucleus.ErrStrategiesIsInvalid)
+	}
+}
+
+func TestCalldataQueueStaleRoot(t *testing.T) {
+	backend, ctx := newBackend(t)
+	client := nucleustest.NewClient(nil)
+
+	queue, err := backend.NewCalldataQueue(client, symbol)
+	if err != nil {
+		t.Fatalf("NewCalldataQueue: %v", err)
+	}
+	queue.AddCall(target, calldata, big.NewInt(0))
+
+	state, err := queue.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	watcher := nucleus.NewManageRootWatcher(callermanageroot.NewManageRootCaller(backend.Registry()))
+	watcher.Watch(queue)
+
+	if err := backend.SetManageRoot(ctx, backend.Strategist(), newRoot); err != nil {
+		t.Fatalf("SetManageRoot: %v", err)
+	}
+
+	if err := watcher.Check(ctx); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if !queue.IsStale() {
+		t.Fatal("queue is not stale after the root changed")
+	}
+
+	if _, err := queue.Execute(ctx); !errors.Is(err, nucleus.ErrStaleManageRoot) {
+		t.Fatalf("Execute error = %v, want %v", err, nucleus.ErrStaleManageRoot)
+	}
+
+	transactor, err := backend.Transactor()
+	if err != nil {
+		t.Fatalf("Transactor: %v", err)
+	}
+
+	restored, err := nucleus.RestoreCalldataQueue(state, client, backend.Registry(), transactor)
+	if err != nil {
+		t.Fatalf("RestoreCalldataQueue: %v", err)
+	}
+	if _, err := restored.Execute(ctx); !errors.Is(err, nucleus.ErrStaleManageRoot) {
+		t.Fatalf("restored Execute error = %v, want %v", err, nucleus.ErrStaleManageRoot)
+	}
+}
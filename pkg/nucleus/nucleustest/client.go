@@ -0,0 +1,141 @@
+// Package nucleustest provides a fake Nucleus API client and a simulated chain with a
+// ManageRoot compatible contract, so CalldataQueue flows can run without network access
+package nucleustest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/nucleus"
+)
+
+// MultiproofsFunc computes the response of a multiproofs request
+type MultiproofsFunc func(chainID int64, root string, txs []nucleus.Transaction) (*nucleus.MerkleProofs, error)
+
+// MultiproofsCall is a multiproofs request received by Client
+type MultiproofsCall struct {
+	ChainID int64
+	Root    string
+	Txs     []nucleus.Transaction
+}
+
+// Client is an in-memory nucleus.IClient serving a fixed address book and generated multiproofs
+type Client struct {
+	mu          sync.Mutex
+	addressBook nucleus.AddressBook
+	decoder     common.Address
+	multiproofs MultiproofsFunc
+	requests    []MultiproofsCall
+}
+
+func NewClient(addressBook nucleus.AddressBook) *Client {
+	if addressBook == nil {
+		addressBook = make(nucleus.AddressBook)
+	}
+
+	return &Client{
+		addressBook: addressBook,
+	}
+}
+
+// SetVault adds or replaces the vault symbol of chainID in the address book
+func (c *Client) SetVault(chainID int64, symbol string, vault nucleus.VaultDetail) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	network := c.addressBook[chainID]
+	if network.Nucleus.Vaults == nil {
+		network.Nucleus.Vaults = make(map[string]nucleus.VaultDetail)
+	}
+	network.Nucleus.Vaults[symbol] = vault
+	c.addressBook[chainID] = network
+}
+
+// SetDecoder sets the decoder returned for calls that do not declare their own
+func (c *Client) SetDecoder(decoder common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.decoder = decoder
+}
+
+// OnMultiproofs replaces the default multiproofs response, e.g. to return an error or wrong decoders
+func (c *Client) OnMultiproofs(fn MultiproofsFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.multiproofs = fn
+}
+
+// MultiproofsRequests returns the multiproofs requests received so far
+func (c *Client) MultiproofsRequests() []MultiproofsCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	requests := make([]MultiproofsCall, len(c.requests))
+	copy(requests, c.requests)
+
+	return requests
+}
+
+func (c *Client) GetMultiproofs(_ context.Context, chainID int64, root string, txs []nucleus.Transaction) (*nucleus.MerkleProofs, error) {
+	c.mu.Lock()
+	c.requests = append(c.requests, MultiproofsCall{
+		ChainID: chainID,
+		Root:    root,
+		Txs:     txs,
+	})
+	multiproofs := c.multiproofs
+	decoder := c.decoder
+	c.mu.Unlock()
+
+	if multiproofs != nil {
+		return multiproofs(chainID, root, txs)
+	}
+
+	// every call gets a single node proof, the mock manager does not verify proofs
+	proofs := &nucleus.MerkleProofs{
+		ManageProofs:          make([][]string, 0, len(txs)),
+		DecodersAndSanitizers: make([]string, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		txDecoder := decoder
+		if tx.DecoderAndSanitizer != (common.Address{}) {
+			txDecoder = tx.DecoderAndSanitizer
+		}
+
+		leaf := crypto.Keccak256Hash(tx.Target.Bytes(), tx.DataBytes)
+		proofs.ManageProofs = append(proofs.ManageProofs, []string{leaf.Hex()})
+		proofs.DecodersAndSanitizers = append(proofs.DecodersAndSanitizers, txDecoder.Hex())
+	}
+
+	return proofs, nil
+}
+
+func (c *Client) GetAddressBook() map[int64]nucleus.NetworkData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.addressBook
+}
+
+func (c *Client) GetVault(chainID int64, symbol string) (nucleus.VaultDetail, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	network, ok := c.addressBook[chainID]
+	if !ok {
+		return nucleus.VaultDetail{}, fmt.Errorf("%w: chainID %d", nucleus.ErrChainNotFound, chainID)
+	}
+
+	vault, ok := network.Nucleus.Vaults[symbol]
+	if !ok {
+		return nucleus.VaultDetail{}, fmt.Errorf("%w: symbol %s on chainID %d", nucleus.ErrVaultNotFound, symbol, chainID)
+	}
+
+	return vault, nil
+}
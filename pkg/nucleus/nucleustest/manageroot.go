@@ -0,0 +1,101 @@
+package nucleustest
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+)
+
+// manageRootLabels are the jump destinations of the ManageRoot mock handlers
+type manageRootLabels struct {
+	manageRoot    uint64
+	setManageRoot uint64
+	manage        uint64
+	zero          uint64
+	revert        uint64
+}
+
+// ManageRootCode returns the runtime code of a minimal ManageRoot compatible contract:
+//   - manageRoot(address) reads the root of the strategist, stored at the slot equal to its address
+//   - setManageRoot(address,bytes32) stores the root without access control and emits ManageRootUpdated
+//   - manageVaultWithMerkleVerification(...) reverts unless the sender has a root, proofs are not
+//     verified and no call is made, it emits BoringVaultManaged with the number of targets
+//   - isPaused() and balancerVault() return zero
+//
+// Every other selector reverts
+func ManageRootCode() []byte {
+	// the labels are pushed with a fixed width so a first pass can resolve their positions
+	labels := buildManageRoot(manageRootLabels{}).labels
+	return buildManageRoot(labels).code.Bytes()
+}
+
+// ManageRootSlot returns the storage slot holding the root of strategist in the ManageRoot mock
+func ManageRootSlot(strategist common.Address) common.Hash {
+	return common.BytesToHash(strategist.Bytes())
+}
+
+type manageRootProgram struct {
+	code   *program.Program
+	labels manageRootLabels
+}
+
+func buildManageRoot(labels manageRootLabels) manageRootProgram {
+	p := program.New()
+	methods := manageroot.ABI.Methods
+
+	// selector
+	p.Push(0).Op(vm.CALLDATALOAD).Push(0xe0).Op(vm.SHR)
+	for _, route := range []struct {
+		selector []byte
+		label    uint64
+	}{
+		{methods["manageRoot"].ID, labels.manageRoot},
+		{methods["setManageRoot"].ID, labels.setManageRoot},
+		{methods["manageVaultWithMerkleVerification"].ID, labels.manage},
+		{methods["isPaused"].ID, labels.zero},
+		{methods["balancerVault"].ID, labels.zero},
+	} {
+		p.Op(vm.DUP1).Push(route.selector).Op(vm.EQ)
+		pushLabel(p, route.label)
+		p.Op(vm.JUMPI)
+	}
+	p.Push(0).Op(vm.DUP1).Op(vm.REVERT)
+
+	// manageRoot(address strategist) returns (bytes32)
+	_, labels.manageRoot = p.Jumpdest()
+	p.Push(4).Op(vm.CALLDATALOAD, vm.SLOAD).Push(0).Op(vm.MSTORE)
+	p.Return(0, 32)
+
+	// isPaused() and balancerVault(), memory is still zeroed
+	_, labels.zero = p.Jumpdest()
+	p.Return(0, 32)
+
+	// setManageRoot(address strategist, bytes32 root)
+	_, labels.setManageRoot = p.Jumpdest()
+	p.Push(4).Op(vm.CALLDATALOAD)
+	p.Op(vm.DUP1, vm.SLOAD).Push(0).Op(vm.MSTORE)
+	p.Push(0x24).Op(vm.CALLDATALOAD, vm.DUP1).Push(0x20).Op(vm.MSTORE)
+	p.Op(vm.DUP2, vm.SSTORE)
+	p.Push(manageroot.ABI.Events["ManageRootUpdated"].ID).Push(0x40).Push(0).Op(vm.LOG2)
+	p.Op(vm.STOP)
+
+	// manageVaultWithMerkleVerification(proofs, decoders, targets, data, values)
+	_, labels.manage = p.Jumpdest()
+	p.Op(vm.CALLER, vm.SLOAD, vm.ISZERO)
+	pushLabel(p, labels.revert)
+	p.Op(vm.JUMPI)
+	p.Push(0x44).Op(vm.CALLDATALOAD).Push(4).Op(vm.ADD, vm.CALLDATALOAD).Push(0).Op(vm.MSTORE)
+	p.Push(manageroot.ABI.Events["BoringVaultManaged"].ID).Push(0x20).Push(0).Op(vm.LOG1)
+	p.Op(vm.STOP)
+
+	_, labels.revert = p.Jumpdest()
+	p.Push(0).Op(vm.DUP1).Op(vm.REVERT)
+
+	return manageRootProgram{code: p, labels: labels}
+}
+
+func pushLabel(p *program.Program, label uint64) {
+	p.Append([]byte{byte(vm.PUSH2), byte(label >> 8), byte(label)})
+}
@@ -0,0 +1,55 @@
+package nucleus
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/goerrors"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/http"
+)
+
+// Get sends a GET request to the Nucleus API and decodes the successful response body into R
+func Get[R any](ctx context.Context, c *Client, endpoint string, queryParams map[string]string) (*R, error) {
+	req := http.R[R, string](c.client)
+	if len(queryParams) > 0 {
+		req.SetQueryParams(queryParams)
+	}
+
+	return execute(req.Get(ctx, endpoint))
+}
+
+// Post sends a POST request with a JSON body to the Nucleus API and decodes the successful response body into R
+func Post[R any](ctx context.Context, c *Client, endpoint string, body any) (*R, error) {
+	return execute(http.R[R, string](c.client).SetBody(body).Post(ctx, endpoint))
+}
+
+func execute[R any](statusCode int, resp *R, errRes *string, err error) (*R, error) {
+	if err != nil {
+		return nil, goerrors.NewErrUnknown(fmt.Errorf("%w: %w", ErrFailedToExecute, err))
+	}
+
+	if errRes != nil {
+		return nil, toGoError(statusCode, fmt.Errorf("%w: status %d, body: %s", ErrFailedToExecute, statusCode, *errRes))
+	}
+
+	return resp, nil
+}
+
+// toGoError maps the HTTP status code of a failed Nucleus API response to a goerrors.Error
+func toGoError(statusCode int, rootCause error) *goerrors.Error {
+	switch statusCode {
+	case nethttp.StatusBadRequest:
+		return goerrors.NewErrInvalid(rootCause)
+	case nethttp.StatusUnauthorized:
+		return goerrors.NewErrUnauthenticated(rootCause)
+	case nethttp.StatusForbidden:
+		return goerrors.NewErrUnauthorized(rootCause)
+	case nethttp.StatusNotFound:
+		return goerrors.NewErrNotFound(rootCause)
+	case nethttp.StatusTooManyRequests:
+		return goerrors.NewErrTooManyRequests(rootCause)
+	default:
+		return goerrors.NewErrUnknown(rootCause)
+	}
+}
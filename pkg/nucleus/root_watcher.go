@@ -0,0 +1,186 @@
+package nucleus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/caller/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+// ManageRootWatcher polls the manage roots of (manager, strategist) pairs, invalidates
+// the watched queues holding a stale root and notifies the registered handlers
+type ManageRootWatcher struct {
+	caller manageroot.ICaller
+
+	mu       sync.Mutex
+	roots    map[rootKey]string
+	queues   map[rootKey]map[*CalldataQueue]struct{}
+	handlers []RootChangeHandler
+}
+
+type rootKey struct {
+	chainID    int64
+	manager    common.Address
+	strategist common.Address
+}
+
+func NewManageRootWatcher(caller manageroot.ICaller) *ManageRootWatcher {
+	return &ManageRootWatcher{
+		caller: caller,
+		roots:  make(map[rootKey]string),
+		queues: make(map[rootKey]map[*CalldataQueue]struct{}),
+	}
+}
+
+// Track starts tracking the manage root of strategist on manager with its current value
+func (w *ManageRootWatcher) Track(ctx context.Context, chainID int64, manager common.Address, strategist common.Address) error {
+	root, err := w.caller.GetManageRootContext(ctx, manager.Hex(), strategist, chainID)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.roots[rootKey{chainID: chainID, manager: manager, strategist: strategist}] = root
+
+	return nil
+}
+
+// Watch tracks the root the queue was built with, the queue is invalidated once the root changes
+func (w *ManageRootWatcher) Watch(queue *CalldataQueue) {
+	key := rootKey{
+		chainID:    queue.chainId,
+		manager:    queue.managerAddress,
+		strategist: common.HexToAddress(queue.strategistAddress),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.roots[key]; !ok {
+		w.roots[key] = queue.root
+	}
+
+	if !strings.EqualFold(w.roots[key], queue.root) {
+		queue.Invalidate()
+		return
+	}
+
+	if w.queues[key] == nil {
+		w.queues[key] = make(map[*CalldataQueue]struct{})
+	}
+	w.queues[key][queue] = struct{}{}
+}
+
+// Unwatch stops watching the queue, e.g. after it has been executed
+func (w *ManageRootWatcher) Unwatch(queue *CalldataQueue) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, queues := range w.queues {
+		delete(queues, queue)
+	}
+}
+
+// OnRootChange registers a handler that is called for every detected root change
+func (w *ManageRootWatcher) OnRootChange(handler RootChangeHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+}
+
+// Check reads the current root of every tracked pair once. A pair whose root cannot be read keeps
+// its last root, the changes of the other pairs are still handled and the read errors are joined
+func (w *ManageRootWatcher) Check(ctx context.Context) error {
+	w.mu.Lock()
+	keys := make([]rootKey, 0, len(w.roots))
+	for key := range w.roots {
+		keys = append(keys, key)
+	}
+	w.mu.Unlock()
+
+	var (
+		changes []RootChange
+		errs    []error
+	)
+	for _, key := range keys {
+		root, err := w.caller.GetManageRootContext(ctx, key.manager.Hex(), key.strategist, key.chainID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("manage root of strategist %s on manager %s (chainID %d): %w",
+				key.strategist.Hex(), key.manager.Hex(), key.chainID, err))
+			continue
+		}
+
+		if change, ok := w.update(key, root); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	w.mu.Lock()
+	handlers := w.handlers
+	w.mu.Unlock()
+
+	for _, change := range changes {
+		logger.Warnf(ctx, "[ManageRootWatcher] Manage root of strategist %s on manager %s (chainID %d) changed from %s to %s",
+			change.Strategist.Hex(), change.Manager.Hex(), change.ChainID, change.OldRoot, change.NewRoot)
+
+		for _, handler := range handlers {
+			handler(change)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Start checks the tracked roots every interval until ctx is done
+func (w *ManageRootWatcher) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.Check(ctx); err != nil {
+					logger.Warnf(ctx, "[ManageRootWatcher] Check manage roots error: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+// update stores the new root of key and invalidates its queues if it changed
+func (w *ManageRootWatcher) update(key rootKey, root string) (RootChange, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	oldRoot := w.roots[key]
+	if strings.EqualFold(oldRoot, root) {
+		return RootChange{}, false
+	}
+
+	w.roots[key] = root
+	for queue := range w.queues[key] {
+		queue.Invalidate()
+	}
+	delete(w.queues, key)
+
+	return RootChange{
+		ChainID:    key.chainID,
+		Manager:    key.manager,
+		Strategist: key.strategist,
+		OldRoot:    oldRoot,
+		NewRoot:    root,
+	}, true
+}
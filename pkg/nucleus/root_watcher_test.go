@@ -0,0 +1,100 @@
+package nucleus
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// stubCaller answers the manage root of every manager from roots, or errs when set
+type stubCaller struct {
+	mu    sync.Mutex
+	roots map[string]string
+	errs  map[string]error
+}
+
+func (c *stubCaller) GetManageRoot(target string, strategist common.Address, chainId int64) (string, error) {
+	return c.GetManageRootContext(context.Background(), target, strategist, chainId)
+}
+
+func (c *stubCaller) GetManageRootContext(_ context.Context, target string, _ common.Address, _ int64) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.errs[target]; err != nil {
+		return "", err
+	}
+
+	return c.roots[target], nil
+}
+
+func TestManageRootWatcherCheckContinuesAfterError(t *testing.T) {
+	ctx := context.Background()
+	strategist := common.HexToAddress("0x01")
+	managers := []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xa2"), common.HexToAddress("0xa3")}
+
+	caller := &stubCaller{roots: make(map[string]string), errs: make(map[string]error)}
+	for _, manager := range managers {
+		caller.roots[manager.Hex()] = "0x01"
+	}
+
+	watcher := NewManageRootWatcher(caller)
+	for _, manager := range managers {
+		if err := watcher.Track(ctx, 1, manager, strategist); err != nil {
+			t.Fatalf("Track: %v", err)
+		}
+	}
+
+	var changes []RootChange
+	watcher.OnRootChange(func(change RootChange) {
+		changes = append(changes, change)
+	})
+
+	failure := errors.New("rpc down")
+	caller.mu.Lock()
+	caller.errs[managers[0].Hex()] = failure
+	caller.roots[managers[1].Hex()] = "0x02"
+	caller.roots[managers[2].Hex()] = "0x02"
+	caller.mu.Unlock()
+
+	err := watcher.Check(ctx)
+	if !errors.Is(err, failure) {
+		t.Fatalf("Check error = %v, want %v", err, failure)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("%d changes handled, want the 2 readable ones", len(changes))
+	}
+	for _, change := range changes {
+		if change.Manager == managers[0] || change.OldRoot != "0x01" || change.NewRoot != "0x02" {
+			t.Fatalf("change = %+v, want a change of a readable manager", change)
+		}
+	}
+
+	// the failed pair kept its root and is reported once it can be read again
+	caller.mu.Lock()
+	delete(caller.errs, managers[0].Hex())
+	caller.roots[managers[0].Hex()] = "0x02"
+	caller.mu.Unlock()
+
+	changes = nil
+	if err := watcher.Check(ctx); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Manager != managers[0] {
+		t.Fatalf("changes = %+v, want the change of the recovered manager", changes)
+	}
+}
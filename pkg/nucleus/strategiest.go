@@ -1,33 +1,47 @@
 package nucleus
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"math/big"
-	"os"
-	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
 )
 
 type IStrategistTransactor interface {
 	GetStrategist(address string) (*bind.TransactOpts, bool)
 }
 
+type IMultiChainStrategistTransactor interface {
+	GetStrategist(chainID int64, address string) (*bind.TransactOpts, error)
+	GetSigner(chainID int64, address string) (adapter.SignerFn, error)
+}
+
 type StrategistTransactor struct {
 	strategistTransactors map[string]*bind.TransactOpts
 }
 
+// NewStrategist builds the transact options of every configured strategist on chainId. It fails with
+// ErrKeySourceMismatch when the key of a config does not belong to its Address, since the transact
+// options are looked up by that address and would otherwise sign for another account
 func NewStrategist(configs []StrategistSignerConfig, chainId int64) (*StrategistTransactor, error) {
 	strategistMap := make(map[string]*bind.TransactOpts)
 	for _, config := range configs {
-		prvKey := os.Getenv(config.Name)
-		pk, err := crypto.HexToECDSA(strings.TrimPrefix(prvKey, "0x"))
+		keySource, err := NewKeySource(config)
 		if err != nil {
-			log.Panicf("Failed to parse private key: %v", err)
+			return nil, err
+		}
+
+		if keySource.Address() != common.HexToAddress(config.Address) {
+			return nil, fmt.Errorf("%w: %s, got %s", ErrKeySourceMismatch, config.Address, keySource.Address().Hex())
 		}
 
-		transactor, err := bind.NewKeyedTransactorWithChainID(pk, new(big.Int).SetInt64(chainId))
+		transactor, err := keySource.TransactOpts(new(big.Int).SetInt64(chainId))
 		if err != nil {
 			return nil, err
 		}
@@ -44,3 +58,76 @@ func (s *StrategistTransactor) GetStrategist(address string) (*bind.TransactOpts
 	strategist, ok := s.strategistTransactors[address]
 	return strategist, ok
 }
+
+// MultiChainStrategistTransactor holds the key sources of all strategists and
+// builds their transact options lazily for every chain they are used on
+type MultiChainStrategistTransactor struct {
+	keySources map[common.Address]IKeySource
+
+	mu          sync.Mutex
+	transactors map[strategistKey]*bind.TransactOpts
+}
+
+type strategistKey struct {
+	chainID int64
+	address common.Address
+}
+
+func NewMultiChainStrategist(configs []StrategistSignerConfig) (*MultiChainStrategistTransactor, error) {
+	keySources := make(map[common.Address]IKeySource)
+	for _, config := range configs {
+		keySource, err := NewKeySource(config)
+		if err != nil {
+			return nil, err
+		}
+
+		address := common.HexToAddress(config.Address)
+		if keySource.Address() != address {
+			return nil, fmt.Errorf("%w: %s, got %s", ErrKeySourceMismatch, config.Address, keySource.Address().Hex())
+		}
+
+		keySources[address] = keySource
+	}
+
+	return &MultiChainStrategistTransactor{
+		keySources:  keySources,
+		transactors: make(map[strategistKey]*bind.TransactOpts),
+	}, nil
+}
+
+func (s *MultiChainStrategistTransactor) GetStrategist(chainID int64, address string) (*bind.TransactOpts, error) {
+	key := strategistKey{chainID: chainID, address: common.HexToAddress(address)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if transactor, ok := s.transactors[key]; ok {
+		return transactor, nil
+	}
+
+	keySource, ok := s.keySources[key.address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStrategistNotFound, address)
+	}
+
+	transactor, err := keySource.TransactOpts(new(big.Int).SetInt64(chainID))
+	if err != nil {
+		return nil, err
+	}
+	s.transactors[key] = transactor
+
+	return transactor, nil
+}
+
+// GetSigner returns a signer of the strategist for chainID which signs with the caller's context
+func (s *MultiChainStrategistTransactor) GetSigner(chainID int64, address string) (adapter.SignerFn, error) {
+	keySource, ok := s.keySources[common.HexToAddress(address)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStrategistNotFound, address)
+	}
+
+	signerChainID := new(big.Int).SetInt64(chainID)
+	return func(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+		return keySource.SignTx(ctx, signerChainID, tx)
+	}, nil
+}
@@ -2,6 +2,7 @@ package nucleus
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +23,24 @@ type HyperswapData struct {
 	NonfungiblePositionManager string `json:"nonfungiblePositionManager"`
 }
 
+// RouterAddress returns the Hyperswap router, it fails when the address book entry is empty or not an address
+func (h HyperswapData) RouterAddress() (common.Address, error) {
+	return hyperswapAddress("router", h.Router)
+}
+
+// PositionManagerAddress returns the Hyperswap position manager, it fails when the address book entry is empty or not an address
+func (h HyperswapData) PositionManagerAddress() (common.Address, error) {
+	return hyperswapAddress("nonfungiblePositionManager", h.NonfungiblePositionManager)
+}
+
+func hyperswapAddress(name string, address string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("%w: hyperswap %s %q", ErrInvalidAddressBookEntry, name, address)
+	}
+
+	return common.HexToAddress(address), nil
+}
+
 type VaultDetail struct {
 	BoringVault    string `json:"boring_vault"`
 	Manager        string `json:"manager"`
@@ -30,6 +49,27 @@ type VaultDetail struct {
 	RolesAuthority string `json:"roles_authority"`
 }
 
+// VaultChange describes a vault whose manager or teller address changed between two address book versions
+type VaultChange struct {
+	ChainID int64
+	Symbol  string
+	Old     VaultDetail
+	New     VaultDetail
+}
+
+type VaultChangeHandler func(change VaultChange)
+
+// RootChange describes a manage root update of a strategist on a manager
+type RootChange struct {
+	ChainID    int64
+	Manager    common.Address
+	Strategist common.Address
+	OldRoot    string
+	NewRoot    string
+}
+
+type RootChangeHandler func(change RootChange)
+
 type ChainConfig struct {
 	Vaults map[string]VaultDetail `json:"-"`
 }
@@ -41,6 +81,16 @@ type Transaction struct {
 	Val       *big.Int       `json:"value"`
 	Data      string         `json:"calldata"`
 	DataBytes []byte
+
+	// FunctionSignature, Args and DecoderAndSanitizer describe the call when it is built by a CallBuilder
+	FunctionSignature   string         `json:"-"`
+	Args                []interface{}  `json:"-"`
+	DecoderAndSanitizer common.Address `json:"-"`
+}
+
+type MultiproofsRequest struct {
+	Chain string        `json:"chain"`
+	Calls []Transaction `json:"calls"`
 }
 
 type MerkleProofs struct {
@@ -56,7 +106,37 @@ type Calldata struct {
 	Values                []*big.Int       `json:"values"`
 }
 
+// VaultState is a snapshot of a vault's on-chain state at BlockNumber
+type VaultState struct {
+	Symbol      string
+	Vault       VaultDetail
+	BlockNumber *big.Int
+
+	// TotalSupply and Decimals of the BoringVault share token
+	TotalSupply *big.Int
+	Decimals    uint8
+
+	// ExchangeRate is the accountant rate of one share in the base asset
+	ExchangeRate *big.Int
+
+	// IsPaused is the paused state of the manager
+	IsPaused bool
+
+	// DepositAssets are the assets supported by the teller
+	DepositAssets []common.Address
+
+	// TokenBalances are the BoringVault balances of the requested assets
+	TokenBalances map[common.Address]*big.Int
+}
+
 type StrategistSignerConfig struct {
 	Address string `json:"address" mapstructure:"address"`
-	Name    string `json:"name" mapstructure:"name"`
+
+	// Name is the environment variable holding the private key, or the keystore passphrase for keystore sources
+	Name string `json:"name" mapstructure:"name"`
+
+	// Source is one of KeySourceEnv (default), KeySourceKeystore or KeySourceRemote
+	Source       string `json:"source" mapstructure:"source"`
+	KeystorePath string `json:"keystorePath" mapstructure:"keystorePath"`
+	RemoteURL    string `json:"remoteUrl" mapstructure:"remoteUrl"`
 }
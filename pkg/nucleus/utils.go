@@ -1,12 +1,35 @@
 package nucleus
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var zeroRoot [32]byte
+
+func rootToHex(root [32]byte) string {
+	return "0x" + hex.EncodeToString(root[:])
+}
+
+func callToTransaction(call *Call) Transaction {
+	return Transaction{
+		Target:              common.HexToAddress(call.TargetAddress),
+		Data:                "0x" + common.Bytes2Hex(call.Data),
+		Val:                 big.NewInt(int64(call.Value)),
+		DataBytes:           call.Data,
+		FunctionSignature:   call.FunctionSignature,
+		Args:                call.Args,
+		DecoderAndSanitizer: common.HexToAddress(call.DecoderAndSanitizer),
+	}
+}
+
 func mappingDecodersAndSanitizers(proofs []string) []common.Address {
 	addresses := make([]common.Address, len(proofs))
 	for i, proof := range proofs {
@@ -15,6 +38,29 @@ func mappingDecodersAndSanitizers(proofs []string) []common.Address {
 	return addresses
 }
 
+// verifyDecodersAndSanitizers checks that the decoders returned with the proofs
+// match the decoders declared by the calls, calls without a declared decoder are skipped
+func verifyDecodersAndSanitizers(txs []Transaction, decodersAndSanitizers []common.Address) error {
+	if len(txs) != len(decodersAndSanitizers) {
+		return fmt.Errorf("%w: got %d decoders for %d calls", ErrUnexpectedDecoder, len(decodersAndSanitizers), len(txs))
+	}
+
+	for i, tx := range txs {
+		if tx.DecoderAndSanitizer == (common.Address{}) {
+			continue
+		}
+
+		if tx.DecoderAndSanitizer != decodersAndSanitizers[i] {
+			return fmt.Errorf(
+				"%w: call %d (%s) expects %s, got %s",
+				ErrUnexpectedDecoder, i, tx.FunctionSignature, tx.DecoderAndSanitizer.Hex(), decodersAndSanitizers[i].Hex(),
+			)
+		}
+	}
+
+	return nil
+}
+
 func mappingManageProofs(proofs [][]string) [][][32]byte {
 	manageProofs := make([][][32]byte, len(proofs))
 	for i, proof := range proofs {
@@ -50,6 +96,44 @@ func parseAddressBook(data []byte) (AddressBook, error) {
 	return book, nil
 }
 
+// diffVaults returns the vaults present in both address books whose manager or teller address changed
+func diffVaults(oldBook, newBook AddressBook) []VaultChange {
+	var changes []VaultChange
+	for chainID, newNetwork := range newBook {
+		oldNetwork, ok := oldBook[chainID]
+		if !ok {
+			continue
+		}
+
+		for symbol, newVault := range newNetwork.Nucleus.Vaults {
+			oldVault, ok := oldNetwork.Nucleus.Vaults[symbol]
+			if !ok {
+				continue
+			}
+
+			if strings.EqualFold(oldVault.Manager, newVault.Manager) && strings.EqualFold(oldVault.Teller, newVault.Teller) {
+				continue
+			}
+
+			changes = append(changes, VaultChange{
+				ChainID: chainID,
+				Symbol:  symbol,
+				Old:     oldVault,
+				New:     newVault,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ChainID != changes[j].ChainID {
+			return changes[i].ChainID < changes[j].ChainID
+		}
+		return changes[i].Symbol < changes[j].Symbol
+	})
+
+	return changes
+}
+
 func (n *ChainConfig) UnmarshalJSON(data []byte) error {
 	var raw rawNucleus
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -0,0 +1,182 @@
+package nucleus
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/accountant"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/erc20"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/manageroot"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/teller"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/rpcregistry"
+)
+
+// VaultReader reads the on-chain state of BoringVault components listed in the Nucleus address book
+type VaultReader struct {
+	client      IClient
+	rpcRegistry rpcregistry.IRegistry
+}
+
+func NewVaultReader(client IClient, rpcRegistry rpcregistry.IRegistry) *VaultReader {
+	return &VaultReader{
+		client:      client,
+		rpcRegistry: rpcRegistry,
+	}
+}
+
+// GetVaultState reads the state of a single vault, see GetVaultStates
+func (r *VaultReader) GetVaultState(ctx context.Context, chainID int64, symbol string, assets []common.Address) (*VaultState, error) {
+	states, err := r.GetVaultStates(ctx, chainID, []string{symbol}, assets)
+	if err != nil {
+		return nil, err
+	}
+
+	return states[symbol], nil
+}
+
+// GetVaultStates reads the state of the given vaults on chainID in a single multicall.
+// assets are the tokens checked against the teller and the vault balances,
+// if empty the tokens of the chain in the address book are used
+func (r *VaultReader) GetVaultStates(ctx context.Context, chainID int64, symbols []string, assets []common.Address) (map[string]*VaultState, error) {
+	if len(assets) == 0 {
+		assets = r.addressBookAssets(chainID)
+	}
+
+	rpcClient, err := r.rpcRegistry.GetRpcClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := rpcClient.NewRequest().SetContext(ctx)
+
+	var requiredCalls []requiredVaultCall
+	states := make(map[string]*VaultState, len(symbols))
+	assetResults := make(map[string][]vaultAssetResult, len(symbols))
+
+	for _, symbol := range symbols {
+		vault, err := r.client.GetVault(chainID, symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		state := &VaultState{
+			Symbol:        symbol,
+			Vault:         vault,
+			TokenBalances: make(map[common.Address]*big.Int, len(assets)),
+		}
+		states[symbol] = state
+
+		requiredCalls = append(requiredCalls,
+			requiredVaultCall{index: len(req.Calls), symbol: symbol, method: "totalSupply"},
+			requiredVaultCall{index: len(req.Calls) + 1, symbol: symbol, method: "decimals"},
+			requiredVaultCall{index: len(req.Calls) + 2, symbol: symbol, method: "getRate"},
+			requiredVaultCall{index: len(req.Calls) + 3, symbol: symbol, method: "isPaused"},
+		)
+
+		req.AddCall(&ethrpc.Call{
+			ABI:    *erc20.ABI,
+			Target: vault.BoringVault,
+			Method: "totalSupply",
+		}, []any{&state.TotalSupply})
+		req.AddCall(&ethrpc.Call{
+			ABI:    *erc20.ABI,
+			Target: vault.BoringVault,
+			Method: "decimals",
+		}, []any{&state.Decimals})
+		req.AddCall(&ethrpc.Call{
+			ABI:    *accountant.ABI,
+			Target: vault.Accountant,
+			Method: "getRate",
+		}, []any{&state.ExchangeRate})
+		req.AddCall(&ethrpc.Call{
+			ABI:    *manageroot.ABI,
+			Target: vault.Manager,
+			Method: "isPaused",
+		}, []any{&state.IsPaused})
+
+		results := make([]vaultAssetResult, len(assets))
+		for i, asset := range assets {
+			results[i] = vaultAssetResult{
+				asset:          asset,
+				supportedIndex: len(req.Calls),
+				balanceIndex:   len(req.Calls) + 1,
+			}
+
+			req.AddCall(&ethrpc.Call{
+				ABI:    *teller.ABI,
+				Target: vault.Teller,
+				Method: "isSupported",
+				Params: []any{asset},
+			}, []any{&results[i].supported})
+			req.AddCall(&ethrpc.Call{
+				ABI:    *erc20.ABI,
+				Target: asset.Hex(),
+				Method: "balanceOf",
+				Params: []any{common.HexToAddress(vault.BoringVault)},
+			}, []any{&results[i].balance})
+		}
+		assetResults[symbol] = results
+	}
+
+	resp, err := req.TryBlockAndAggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, call := range requiredCalls {
+		if !resp.Result[call.index] {
+			return nil, fmt.Errorf("%w: %s %s on chainID %d", ErrReadVaultStateFailed, call.symbol, call.method, chainID)
+		}
+	}
+
+	for symbol, state := range states {
+		state.BlockNumber = resp.BlockNumber
+
+		for _, result := range assetResults[symbol] {
+			if resp.Result[result.supportedIndex] && result.supported {
+				state.DepositAssets = append(state.DepositAssets, result.asset)
+			}
+
+			if resp.Result[result.balanceIndex] && result.balance != nil {
+				state.TokenBalances[result.asset] = result.balance
+			}
+		}
+	}
+
+	return states, nil
+}
+
+func (r *VaultReader) addressBookAssets(chainID int64) []common.Address {
+	network, ok := r.client.GetAddressBook()[chainID]
+	if !ok {
+		return nil
+	}
+
+	assets := make([]common.Address, 0, len(network.Token))
+	for _, token := range network.Token {
+		if !common.IsHexAddress(token) {
+			continue
+		}
+		assets = append(assets, common.HexToAddress(token))
+	}
+
+	return assets
+}
+
+type requiredVaultCall struct {
+	index  int
+	symbol string
+	method string
+}
+
+type vaultAssetResult struct {
+	asset          common.Address
+	supportedIndex int
+	balanceIndex   int
+	supported      bool
+	balance        *big.Int
+}
@@ -0,0 +1,13 @@
+package rpcregistry
+
+import (
+	"context"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+)
+
+// Handle exposes handle to the tests of rpcregistry_test, which import adaptertest
+func (s *HeadStream) Handle(ctx context.Context, source adapter.EthClientAdapter, header *types.Header) {
+	s.handle(ctx, source, header)
+}
@@ -0,0 +1,459 @@
+package rpcregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	DefaultHealthCheckInterval = 15 * time.Second
+	DefaultHealthCheckTimeout  = 5 * time.Second
+	DefaultMaxBlockLag         = 20
+	DefaultMaxErrorRate        = 0.5
+	DefaultMaxRetries          = 2
+	DefaultRetryBackoff        = 200 * time.Millisecond
+
+	// minErrorRateSamples is the number of requests below which the error rate is not judged
+	minErrorRateSamples = 10
+)
+
+var (
+	ErrNoEndpoints = errors.New("no RPC endpoint configured")
+
+	// nonIdempotentMethods are never retried, resending them may fail or act twice
+	nonIdempotentMethods = map[string]struct{}{
+		"eth_sendRawTransaction": {},
+		"eth_sendTransaction":    {},
+	}
+
+	// retryableErrorCodes are the JSON-RPC error codes of rate limited requests
+	retryableErrorCodes = map[int]struct{}{
+		-32005: {}, // limit exceeded
+		-32029: {}, // too many requests
+	}
+
+	// retryableErrorMessages are the errors of nodes that are rate limiting or have not synced the requested block yet
+	retryableErrorMessages = []string{
+		"rate limit",
+		"too many requests",
+		"header not found",
+		"unknown block",
+		"missing trie node",
+	}
+
+	blockNumberRequest = []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+)
+
+type endpoint struct {
+	url    *url.URL
+	weight int
+
+	mu       sync.Mutex
+	healthy  bool
+	requests int
+	failures int
+}
+
+func (e *endpoint) record(failed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.requests++
+	if failed {
+		e.failures++
+	}
+}
+
+// endpointPool is an http.RoundTripper spreading JSON-RPC requests over the endpoints of a chain.
+// Endpoints are picked by weight among the healthy ones, failed reads are retried on another endpoint
+type endpointPool struct {
+	chainID   int64
+	endpoints []*endpoint
+	config    FailoverConfig
+	transport http.RoundTripper
+}
+
+func newEndpointPool(chainID int64, cfg ChainConfig) (*endpointPool, error) {
+	endpointConfigs := cfg.endpoints()
+	if len(endpointConfigs) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	endpoints := make([]*endpoint, 0, len(endpointConfigs))
+	for _, endpointConfig := range endpointConfigs {
+		u, err := url.Parse(endpointConfig.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RPC endpoint %q: %w", endpointConfig.URL, err)
+		}
+
+		weight := endpointConfig.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		endpoints = append(endpoints, &endpoint{
+			url:     u,
+			weight:  weight,
+			healthy: true,
+		})
+	}
+
+	config := cfg.Failover
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if config.HealthCheckTimeout <= 0 {
+		config.HealthCheckTimeout = DefaultHealthCheckTimeout
+	}
+	if config.MaxBlockLag == nil {
+		maxBlockLag := uint64(DefaultMaxBlockLag)
+		config.MaxBlockLag = &maxBlockLag
+	}
+	if config.MaxErrorRate <= 0 {
+		config.MaxErrorRate = DefaultMaxErrorRate
+	}
+	if config.MaxRetries == nil {
+		maxRetries := DefaultMaxRetries
+		config.MaxRetries = &maxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+
+	return &endpointPool{
+		chainID:   chainID,
+		endpoints: endpoints,
+		config:    config,
+		transport: http.DefaultTransport,
+	}, nil
+}
+
+// dialURL is the URL the RPC clients are dialed with, the pool rewrites it per request
+func (p *endpointPool) dialURL() string {
+	return p.endpoints[0].url.String()
+}
+
+func (p *endpointPool) httpClient() *http.Client {
+	return &http.Client{Transport: p}
+}
+
+func (p *endpointPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempts := 1
+	idempotent := isIdempotent(body)
+	if idempotent {
+		attempts += max(*p.config.MaxRetries, 0)
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	tried := make(map[*endpoint]struct{}, len(p.endpoints))
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(p.config.RetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		e := p.pick(tried)
+		tried[e] = struct{}{}
+
+		resp, err = p.transport.RoundTrip(e.request(req, body))
+		failed := err != nil || isRetryableStatus(resp.StatusCode)
+		if !failed && idempotent {
+			// a node rate limiting or lagging behind may still answer 200 with a JSON-RPC error
+			failed, err = hasRetryableError(resp)
+		}
+		e.record(failed)
+
+		if !failed || attempt == attempts-1 {
+			break
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+
+	if err != nil {
+		// the last attempt may have a response whose body could not be read
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// pick returns a healthy endpoint by weight, preferring endpoints not tried yet
+func (p *endpointPool) pick(tried map[*endpoint]struct{}) *endpoint {
+	candidates := p.filter(func(e *endpoint) bool {
+		_, ok := tried[e]
+		return !ok && e.isHealthy()
+	})
+	if len(candidates) == 0 {
+		candidates = p.filter(func(e *endpoint) bool {
+			_, ok := tried[e]
+			return !ok
+		})
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	total := 0
+	for _, e := range candidates {
+		total += e.weight
+	}
+
+	n := rand.Intn(total)
+	for _, e := range candidates {
+		if n < e.weight {
+			return e
+		}
+		n -= e.weight
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+func (p *endpointPool) filter(fn func(*endpoint) bool) []*endpoint {
+	var endpoints []*endpoint
+	for _, e := range p.endpoints {
+		if fn(e) {
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	return endpoints
+}
+
+// run checks the health of the endpoints every health check interval until ctx is done
+func (p *endpointPool) run(ctx context.Context) {
+	if len(p.endpoints) < 2 {
+		// a single endpoint is always used, there is nothing to fail over to
+		return
+	}
+
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkHealth(ctx)
+		}
+	}
+}
+
+func (p *endpointPool) checkHealth(ctx context.Context) {
+	blockNumbers := make([]uint64, len(p.endpoints))
+	probeErrors := make([]error, len(p.endpoints))
+
+	wg := sync.WaitGroup{}
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			blockNumbers[i], probeErrors[i] = p.probe(ctx, e)
+		}()
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i := range p.endpoints {
+		if probeErrors[i] == nil {
+			highest = max(highest, blockNumbers[i])
+		}
+	}
+
+	for i, e := range p.endpoints {
+		e.mu.Lock()
+		errorRate := 0.0
+		if e.requests >= minErrorRateSamples {
+			errorRate = float64(e.failures) / float64(e.requests)
+		}
+
+		e.healthy = probeErrors[i] == nil &&
+			highest-blockNumbers[i] <= *p.config.MaxBlockLag &&
+			errorRate <= p.config.MaxErrorRate
+		e.requests = 0
+		e.failures = 0
+		e.mu.Unlock()
+	}
+}
+
+// probe returns the latest block number of the endpoint
+func (p *endpointPool) probe(ctx context.Context, e *endpoint) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.HealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.transport.RoundTrip(e.request(req, blockNumberRequest))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("RPC endpoint of chainID %d responded %s", p.chainID, resp.Status)
+	}
+
+	var result struct {
+		Result *hexutil.Uint64 `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	if result.Result == nil {
+		return 0, fmt.Errorf("RPC endpoint of chainID %d returned no block number", p.chainID)
+	}
+
+	return uint64(*result.Result), nil
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.healthy
+}
+
+// request clones req to be sent to the endpoint with body
+func (e *endpoint) request(req *http.Request, body []byte) *http.Request {
+	u := *e.url
+
+	out := req.Clone(req.Context())
+	out.URL = &u
+	out.Host = ""
+	if e.url.User != nil {
+		password, _ := e.url.User.Password()
+		out.SetBasicAuth(e.url.User.Username(), password)
+	}
+
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return out
+}
+
+// isIdempotent reports whether every JSON-RPC call in body may be resent
+func isIdempotent(body []byte) bool {
+	type message struct {
+		Method string `json:"method"`
+	}
+
+	var messages []message
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &messages); err != nil {
+			return false
+		}
+	} else {
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return false
+		}
+		messages = append(messages, msg)
+	}
+
+	for _, msg := range messages {
+		if _, ok := nonIdempotentMethods[msg.Method]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// hasRetryableError reports whether the JSON-RPC response, or a response of the batch, holds an error
+// another endpoint may not return. The body is read and replaced so resp can still be returned
+func hasRetryableError(resp *http.Response) (bool, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+
+	type message struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	var messages []message
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &messages); err != nil {
+			return false, nil
+		}
+	} else {
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return false, nil
+		}
+		messages = append(messages, msg)
+	}
+
+	for _, msg := range messages {
+		if msg.Error == nil {
+			continue
+		}
+
+		if _, ok := retryableErrorCodes[msg.Error.Code]; ok {
+			return true, nil
+		}
+
+		for _, retryableMessage := range retryableErrorMessages {
+			if strings.Contains(strings.ToLower(msg.Error.Message), retryableMessage) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,305 @@
+package rpcregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// node is a JSON-RPC endpoint answering every request with status and body
+type node struct {
+	requests atomic.Int64
+
+	status int
+	body   string
+}
+
+func newNode(t *testing.T, status int, body string) (*node, string) {
+	t.Helper()
+
+	n := &node{
+		status: status,
+		body:   body,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.requests.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(n.status)
+		_, _ = w.Write([]byte(n.body))
+	}))
+	t.Cleanup(server.Close)
+
+	return n, server.URL
+}
+
+func result(value string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%q}`, value)
+}
+
+func rpcError(code int, message string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"error":{"code":%d,"message":%q}}`, code, message)
+}
+
+func newPool(t *testing.T, failover FailoverConfig, urls ...string) *endpointPool {
+	t.Helper()
+
+	cfg := ChainConfig{Failover: failover}
+	for _, url := range urls {
+		cfg.Endpoints = append(cfg.Endpoints, EndpointConfig{URL: url})
+	}
+
+	pool, err := newEndpointPool(1, cfg)
+	if err != nil {
+		t.Fatalf("newEndpointPool: %v", err)
+	}
+
+	return pool
+}
+
+// call sends a JSON-RPC request through the pool and returns the result or the JSON-RPC error
+func call(t *testing.T, pool *endpointPool, method string) (string, error) {
+	t.Helper()
+
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":[]}`, method)
+	resp, err := pool.httpClient().Post(pool.dialURL(), "application/json", strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var msg struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return "", fmt.Errorf("status %d: %w", resp.StatusCode, err)
+	}
+	if msg.Error != nil {
+		return "", errors.New(msg.Error.Message)
+	}
+
+	return msg.Result, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestEndpointPoolRetries(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"rate limited status", http.StatusTooManyRequests, ""},
+		{"server error", http.StatusBadGateway, ""},
+		{"rate limited JSON-RPC error", http.StatusOK, rpcError(-32005, "limit exceeded")},
+		{"lagging node", http.StatusOK, rpcError(-32000, "header not found")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing, failingURL := newNode(t, tt.status, tt.body)
+			healthy, healthyURL := newNode(t, http.StatusOK, result("0x1"))
+
+			pool := newPool(t, FailoverConfig{RetryBackoff: time.Millisecond}, failingURL, healthyURL)
+
+			// endpoints are picked at random, enough calls make sure the failing one is picked first
+			for i := 0; i < 50; i++ {
+				value, err := call(t, pool, "eth_blockNumber")
+				if err != nil || value != "0x1" {
+					t.Fatalf("call = %q, %v, want 0x1", value, err)
+				}
+			}
+
+			if healthy.requests.Load() != 50 {
+				t.Fatalf("healthy endpoint got %d requests, want 50", healthy.requests.Load())
+			}
+			if failing.requests.Load() == 0 {
+				t.Fatal("failing endpoint got no request")
+			}
+		})
+	}
+}
+
+func TestEndpointPoolDoesNotRetryRevertedCalls(t *testing.T) {
+	first, firstURL := newNode(t, http.StatusOK, rpcError(3, "execution reverted"))
+	second, secondURL := newNode(t, http.StatusOK, rpcError(3, "execution reverted"))
+
+	pool := newPool(t, FailoverConfig{RetryBackoff: time.Millisecond}, firstURL, secondURL)
+
+	if _, err := call(t, pool, "eth_call"); err == nil || err.Error() != "execution reverted" {
+		t.Fatalf("call error = %v, want execution reverted", err)
+	}
+
+	if requests := first.requests.Load() + second.requests.Load(); requests != 1 {
+		t.Fatalf("endpoints got %d requests, want 1", requests)
+	}
+}
+
+func TestEndpointPoolDoesNotRetryTransactions(t *testing.T) {
+	first, firstURL := newNode(t, http.StatusTooManyRequests, "")
+	second, secondURL := newNode(t, http.StatusTooManyRequests, "")
+
+	pool := newPool(t, FailoverConfig{RetryBackoff: time.Millisecond}, firstURL, secondURL)
+
+	if _, err := call(t, pool, "eth_sendRawTransaction"); err == nil {
+		t.Fatal("call succeeded, want the rate limit to be returned")
+	}
+
+	if requests := first.requests.Load() + second.requests.Load(); requests != 1 {
+		t.Fatalf("endpoints got %d requests, want 1", requests)
+	}
+}
+
+func TestEndpointPoolWithoutRetries(t *testing.T) {
+	first, firstURL := newNode(t, http.StatusTooManyRequests, "")
+	second, secondURL := newNode(t, http.StatusTooManyRequests, "")
+
+	pool := newPool(t, FailoverConfig{MaxRetries: ptr(0)}, firstURL, secondURL)
+
+	if _, err := call(t, pool, "eth_blockNumber"); err == nil {
+		t.Fatal("call succeeded, want the rate limit to be returned")
+	}
+
+	if requests := first.requests.Load() + second.requests.Load(); requests != 1 {
+		t.Fatalf("endpoints got %d requests, want 1", requests)
+	}
+}
+
+// failingBody is a response body failing to be read
+type failingBody struct {
+	closed bool
+}
+
+func (b *failingBody) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func (b *failingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestEndpointPoolUnreadableBody(t *testing.T) {
+	_, url := newNode(t, http.StatusOK, result("0x1"))
+	pool := newPool(t, FailoverConfig{MaxRetries: ptr(0)}, url)
+
+	body := &failingBody{}
+	pool.transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: body, Request: req}, nil
+	})
+
+	req, err := http.NewRequest(http.MethodPost, pool.dialURL(), strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := pool.RoundTrip(req)
+	if err == nil || resp != nil {
+		t.Fatalf("RoundTrip = %v, %v, want only the read error", resp, err)
+	}
+	if !body.closed {
+		t.Fatal("the unreadable body was not closed")
+	}
+}
+
+func TestEndpointPoolHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxBlockLag *uint64
+		lagging     string
+		wantHealthy bool
+	}{
+		{"within the default lag", nil, "0x60", true},
+		{"beyond the default lag", nil, "0x4f", false},
+		{"zero lag", ptr(uint64(0)), "0x63", false},
+		{"probe failing", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, headURL := newNode(t, http.StatusOK, result("0x64"))
+
+			status, body := http.StatusOK, result(tt.lagging)
+			if tt.lagging == "" {
+				status, body = http.StatusInternalServerError, ""
+			}
+			lagging, laggingURL := newNode(t, status, body)
+
+			pool := newPool(t, FailoverConfig{MaxBlockLag: tt.maxBlockLag}, headURL, laggingURL)
+			pool.checkHealth(context.Background())
+
+			if healthy := pool.endpoints[1].isHealthy(); healthy != tt.wantHealthy {
+				t.Fatalf("lagging endpoint healthy = %t, want %t", healthy, tt.wantHealthy)
+			}
+			if !pool.endpoints[0].isHealthy() {
+				t.Fatal("head endpoint is unhealthy")
+			}
+
+			lagging.requests.Store(0)
+			for i := 0; i < 10; i++ {
+				if _, err := call(t, pool, "eth_blockNumber"); err != nil {
+					t.Fatalf("call: %v", err)
+				}
+			}
+			if !tt.wantHealthy && lagging.requests.Load() != 0 {
+				t.Fatalf("unhealthy endpoint got %d requests, want 0", lagging.requests.Load())
+			}
+		})
+	}
+}
+
+func TestEndpointPoolHealthCheckErrorRate(t *testing.T) {
+	_, firstURL := newNode(t, http.StatusOK, result("0x64"))
+	_, secondURL := newNode(t, http.StatusOK, result("0x64"))
+
+	pool := newPool(t, FailoverConfig{}, firstURL, secondURL)
+
+	for i := 0; i < minErrorRateSamples; i++ {
+		pool.endpoints[0].record(false)
+		pool.endpoints[1].record(i%4 != 0)
+	}
+
+	pool.checkHealth(context.Background())
+
+	if !pool.endpoints[0].isHealthy() || pool.endpoints[1].isHealthy() {
+		t.Fatalf("healthy = %t, %t, want true, false", pool.endpoints[0].isHealthy(), pool.endpoints[1].isHealthy())
+	}
+
+	// the counters restart every interval, so the endpoint recovers once it stops failing
+	pool.checkHealth(context.Background())
+
+	if !pool.endpoints[1].isHealthy() {
+		t.Fatal("endpoint did not recover")
+	}
+}
+
+func TestNewRpcRegistryWithoutEndpoints(t *testing.T) {
+	_, url := newNode(t, http.StatusOK, result("0x1"))
+
+	_, err := NewRpcRegistry(Config{
+		1: {HTTP: url},
+		2: {},
+	})
+	if !errors.Is(err, ErrNoEndpoints) {
+		t.Fatalf("NewRpcRegistry error = %v, want %v", err, ErrNoEndpoints)
+	}
+}
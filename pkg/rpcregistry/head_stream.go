@@ -0,0 +1,274 @@
+package rpcregistry
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+)
+
+const (
+	DefaultHeadBufferSize = 64
+
+	// MaxHeadBackfill is the largest gap backfilled after a reconnect, older missed heads are skipped
+	MaxHeadBackfill = 128
+
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+
+	// backfillAttempts is how many times a missed head is fetched before the backfill waits for the next head
+	backfillAttempts   = 3
+	backfillRetryDelay = 500 * time.Millisecond
+)
+
+var (
+	ErrNoWSEndpoint = errors.New("no WS endpoint configured")
+)
+
+// HeadStream subscribes to the new heads of a chain over WS and fans them out to its subscribers.
+// A dropped subscription is redialed with backoff and the heads missed meanwhile are backfilled
+// from the WS node, so subscribers see consecutive heads unless more than MaxHeadBackfill heads
+// were missed, the older ones are then skipped. A head whose missed heads cannot be fetched is
+// held back, the next head retries the backfill. The subscriptions are closed once the stream stops
+type HeadStream struct {
+	chainID int64
+	dial    func(ctx context.Context) (adapter.EthClientAdapter, func(), error)
+
+	startOnce sync.Once
+
+	mu          sync.Mutex
+	subscribers map[*HeadSubscription]struct{}
+	last        *types.Header
+	stopped     bool
+}
+
+// HeadSubscription receives the heads of a HeadStream until it is unsubscribed
+type HeadSubscription struct {
+	stream  *HeadStream
+	headers chan *types.Header
+	once    sync.Once
+}
+
+func newHeadStream(chainID int64, wsURL string) *HeadStream {
+	return &HeadStream{
+		chainID: chainID,
+		dial: func(ctx context.Context) (adapter.EthClientAdapter, func(), error) {
+			client, err := ethclient.DialContext(ctx, wsURL)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return adapter.NewFromClient(client), client.Close, nil
+		},
+		subscribers: make(map[*HeadSubscription]struct{}),
+	}
+}
+
+// NewHeadStream creates a stream of the heads of source, e.g. of a simulated chain, source must
+// support SubscribeNewHead and is not closed by the stream
+func NewHeadStream(chainID int64, source adapter.EthClientAdapter) *HeadStream {
+	return &HeadStream{
+		chainID: chainID,
+		dial: func(context.Context) (adapter.EthClientAdapter, func(), error) {
+			return source, func() {}, nil
+		},
+		subscribers: make(map[*HeadSubscription]struct{}),
+	}
+}
+
+// Subscribe adds a subscriber whose channel buffers bufferSize heads, a subscriber that falls
+// further behind misses heads instead of stalling the others
+func (s *HeadStream) Subscribe(bufferSize int) *HeadSubscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultHeadBufferSize
+	}
+
+	sub := &HeadSubscription{
+		stream:  s,
+		headers: make(chan *types.Header, bufferSize),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		sub.close()
+		return sub
+	}
+	s.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Headers returns the channel of new heads, it is closed by Unsubscribe or once the stream stops
+func (s *HeadSubscription) Headers() <-chan *types.Header {
+	return s.headers
+}
+
+func (s *HeadSubscription) Unsubscribe() {
+	s.stream.mu.Lock()
+	defer s.stream.mu.Unlock()
+
+	delete(s.stream.subscribers, s)
+	s.close()
+}
+
+func (s *HeadSubscription) close() {
+	s.once.Do(func() {
+		close(s.headers)
+	})
+}
+
+// Start runs the stream until ctx is done, only the first call has an effect
+func (s *HeadStream) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		go s.run(ctx)
+	})
+}
+
+func (s *HeadStream) run(ctx context.Context) {
+	defer s.stop()
+
+	delay := minReconnectDelay
+	for {
+		received, err := s.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			delay = minReconnectDelay
+		}
+
+		logger.Warnf(ctx, "[HeadStream] New head subscription of chainID %d dropped, reconnecting in %s, err: %s", s.chainID, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// stop closes the subscriptions, later subscriptions are closed right away
+func (s *HeadStream) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		sub.close()
+	}
+}
+
+// subscribe dials the source of the heads and forwards its heads until the subscription fails,
+// received reports whether any head arrived
+func (s *HeadStream) subscribe(ctx context.Context) (received bool, err error) {
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	source, closeSource, err := s.dial(connCtx)
+	if err != nil {
+		return false, err
+	}
+	defer closeSource()
+
+	headers := make(chan *types.Header)
+	sub, err := source.SubscribeNewHead(connCtx, headers)
+	if err != nil {
+		return false, err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return received, ctx.Err()
+		case err := <-sub.Err():
+			return received, err
+		case header := <-headers:
+			received = true
+			s.handle(ctx, source, header)
+		}
+	}
+}
+
+// handle backfills the heads missed before header from source and broadcasts them. The node that
+// announced header has its ancestors, unlike endpoints lagging behind it. When a missed head cannot
+// be fetched header is not broadcast and last stays at the last consecutive head
+func (s *HeadStream) handle(ctx context.Context, source adapter.EthClientAdapter, header *types.Header) {
+	s.mu.Lock()
+	last := s.last
+	s.mu.Unlock()
+
+	if last != nil && last.Hash == header.Hash {
+		return
+	}
+
+	if last != nil && header.Number.Cmp(last.Number) > 0 {
+		from := new(big.Int).Add(last.Number, big.NewInt(1))
+		if gap := new(big.Int).Sub(header.Number, from); gap.Cmp(big.NewInt(MaxHeadBackfill)) > 0 {
+			skipped := from
+			from = new(big.Int).Sub(header.Number, big.NewInt(MaxHeadBackfill))
+			logger.Warnf(ctx, "[HeadStream] Skipped headers %s to %s of chainID %d, the gap exceeds %d",
+				skipped, new(big.Int).Sub(from, big.NewInt(1)), s.chainID, MaxHeadBackfill)
+		}
+
+		for number := from; number.Cmp(header.Number) < 0; number = new(big.Int).Add(number, big.NewInt(1)) {
+			missed, err := s.fetchHeader(ctx, source, number)
+			if err != nil {
+				logger.Warnf(ctx, "[HeadStream] Backfill header %s of chainID %d error, holding back header %s: %s",
+					number, s.chainID, header.Number, err)
+				return
+			}
+			s.broadcast(ctx, missed)
+		}
+	}
+
+	s.broadcast(ctx, header)
+}
+
+// fetchHeader returns the header at number, retrying up to backfillAttempts times
+func (s *HeadStream) fetchHeader(ctx context.Context, source adapter.EthClientAdapter, number *big.Int) (*types.Header, error) {
+	var err error
+	for attempt := 0; attempt < backfillAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backfillRetryDelay):
+			}
+		}
+
+		var header *types.Header
+		header, err = source.HeaderByNumber(ctx, number)
+		if err == nil {
+			return header, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (s *HeadStream) broadcast(ctx context.Context, header *types.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = header
+	for sub := range s.subscribers {
+		select {
+		case sub.headers <- header:
+		default:
+			logger.Warnf(ctx, "[HeadStream] Subscriber of chainID %d is full, dropped header %s", s.chainID, header.Number)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package rpcregistry_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/adaptertest"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc/adapter/types"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/logger"
+	"github.com/Tempest-Finance/console-strategies-common/pkg/rpcregistry"
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.InitLogger(0); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// received drains the heads buffered for sub and returns their numbers
+func received(sub *rpcregistry.HeadSubscription) []int64 {
+	var numbers []int64
+	for {
+		select {
+		case header := <-sub.Headers():
+			numbers = append(numbers, header.Number.Int64())
+		default:
+			return numbers
+		}
+	}
+}
+
+func TestHeadStreamBackfill(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	stream := rpcregistry.NewHeadStream(1, fake)
+	sub := stream.Subscribe(0)
+	defer sub.Unsubscribe()
+
+	stream.Handle(ctx, fake, fake.Mine())
+	for i := 0; i < 3; i++ {
+		fake.Mine()
+	}
+	head := fake.Mine()
+
+	stream.Handle(ctx, fake, head)
+	stream.Handle(ctx, fake, head)
+
+	if numbers := received(sub); !slices.Equal(numbers, []int64{1, 2, 3, 4, 5}) {
+		t.Fatalf("received heads %v, want 1 to 5", numbers)
+	}
+}
+
+func TestHeadStreamHoldsBackHeadsUntilBackfilled(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	stream := rpcregistry.NewHeadStream(1, fake)
+	sub := stream.Subscribe(0)
+	defer sub.Unsubscribe()
+
+	first := fake.Mine()
+	stream.Handle(ctx, fake, first)
+
+	// the node has not got the headers between first and the head yet
+	stream.Handle(ctx, fake, fake.AddHeader(&types.Header{Number: big.NewInt(4)}))
+
+	if numbers := received(sub); !slices.Equal(numbers, []int64{1}) {
+		t.Fatalf("received heads %v, want only 1 while the backfill fails", numbers)
+	}
+
+	fake.AddHeader(&types.Header{ParentHash: first.Hash, Number: big.NewInt(2)})
+	for i := 0; i < 2; i++ {
+		fake.Mine()
+	}
+	stream.Handle(ctx, fake, fake.Mine())
+
+	if numbers := received(sub); !slices.Equal(numbers, []int64{2, 3, 4, 5}) {
+		t.Fatalf("received heads %v, want 2 to 5", numbers)
+	}
+}
+
+func TestHeadStreamSkipsLargeGaps(t *testing.T) {
+	ctx := context.Background()
+	fake := adaptertest.NewFake()
+
+	stream := rpcregistry.NewHeadStream(1, fake)
+	sub := stream.Subscribe(rpcregistry.MaxHeadBackfill + 2)
+	defer sub.Unsubscribe()
+
+	stream.Handle(ctx, fake, fake.Mine())
+	for i := 0; i < rpcregistry.MaxHeadBackfill+10; i++ {
+		fake.Mine()
+	}
+	head := fake.Mine()
+	stream.Handle(ctx, fake, head)
+
+	numbers := received(sub)
+	if len(numbers) != rpcregistry.MaxHeadBackfill+2 || numbers[1] != head.Number.Int64()-rpcregistry.MaxHeadBackfill {
+		t.Fatalf("received %d heads starting %v, want the first head and the last %d", len(numbers), numbers[:2], rpcregistry.MaxHeadBackfill+1)
+	}
+}
+
+func TestHeadStreamClosesSubscriptionsWhenStopped(t *testing.T) {
+	fake := adaptertest.NewFake()
+
+	stream := rpcregistry.NewHeadStream(1, fake)
+	sub := stream.Subscribe(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream.Start(ctx)
+
+	// heads mined before the stream subscribed to the fake are not sent
+	timeout := time.After(5 * time.Second)
+	for received := false; !received; {
+		fake.Mine()
+		select {
+		case <-sub.Headers():
+			received = true
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("no head received")
+		}
+	}
+
+	cancel()
+
+	// the channel is closed, possibly after heads still buffered
+	timeout = time.After(5 * time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-sub.Headers():
+			closed = !ok
+		case <-timeout:
+			t.Fatal("subscription not closed after the stream stopped")
+		}
+	}
+	sub.Unsubscribe()
+
+	late := stream.Subscribe(0)
+	if _, ok := <-late.Headers(); ok {
+		t.Fatal("subscription after the stream stopped received a head")
+	}
+	late.Unsubscribe()
+}
@@ -12,4 +12,7 @@ type IRegistry interface {
 
 	// GetRpcClient returns the rpc client for a given chain
 	GetRpcClient(chainID int64) (*ethrpc.Client, error)
+
+	// SubscribeNewHeads subscribes to the new heads of a given chain, bufferSize heads are buffered
+	SubscribeNewHeads(chainID int64, bufferSize int) (*HeadSubscription, error)
 }
@@ -1,8 +1,11 @@
 package rpcregistry
 
 import (
+	"context"
 	"fmt"
+
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/Tempest-Finance/console-strategies-common/pkg/abi/multicall3"
 	"github.com/Tempest-Finance/console-strategies-common/pkg/ethrpc"
@@ -11,40 +14,62 @@ import (
 
 // RpcRegistry is a collection of clients for different chains
 type RpcRegistry struct {
-	clientByChainID    map[int64]*ethclient.Client
-	rpcClientByChainID map[int64]*ethrpc.Client
+	clientByChainID     map[int64]*ethclient.Client
+	rpcClientByChainID  map[int64]*ethrpc.Client
+	headStreamByChainID map[int64]*HeadStream
+
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
+// NewRpcRegistry dials every chain through its endpoints and starts their health checks,
+// Close stops them
 func NewRpcRegistry(config Config) (*RpcRegistry, error) {
-	clientByChainID := make(map[int64]*ethclient.Client)
-	rpcClientByChainID := make(map[int64]*ethrpc.Client)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	registry := &RpcRegistry{
+		clientByChainID:     make(map[int64]*ethclient.Client),
+		rpcClientByChainID:  make(map[int64]*ethrpc.Client),
+		headStreamByChainID: make(map[int64]*HeadStream),
+		ctx:                 ctx,
+		cancel:              cancel,
+	}
 
 	for chainID, cfg := range config {
-		client, err := ethclient.Dial(cfg.HTTP)
+		pool, err := newEndpointPool(chainID, cfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to dial RPC for chainID %d: %w", chainID, err)
+			registry.Close()
+			return nil, fmt.Errorf("failed to configure RPC endpoints for chainID %d: %w", chainID, err)
 		}
 
-		ethClientAdapter, err := adapter.New(cfg.HTTP)
+		rpcClient, err := rpc.DialOptions(ctx, pool.dialURL(), rpc.WithHTTPClient(pool.httpClient()))
 		if err != nil {
-			return nil, fmt.Errorf("failed to create eth client adapter for chainID %d: %w", chainID, err)
+			registry.Close()
+			return nil, fmt.Errorf("failed to dial RPC for chainID %d: %w", chainID, err)
 		}
 
-		rpcClient := ethrpc.NewClient(
+		// the eth client and the adapter share one connection, so both fail over
+		client := ethclient.NewClient(rpcClient)
+		ethClientAdapter := adapter.NewFromClient(client)
+
+		ethrpcClient := ethrpc.NewClient(
 			ethrpc.WithEthClientAdapter(ethClientAdapter),
 			ethrpc.WithMulticall(cfg.MulticallAddress, multicall3.ABI),
 			ethrpc.WithRequestMiddlewares(ethrpc.ParseRequestMiddleware),
 			ethrpc.WithResponseMiddlewares(ethrpc.ParseResponseMiddleware),
 		)
 
-		clientByChainID[chainID] = client
-		rpcClientByChainID[chainID] = rpcClient
+		go pool.run(ctx)
+
+		registry.clientByChainID[chainID] = client
+		registry.rpcClientByChainID[chainID] = ethrpcClient
+
+		if cfg.WS != "" {
+			registry.headStreamByChainID[chainID] = newHeadStream(chainID, cfg.WS)
+		}
 	}
 
-	return &RpcRegistry{
-		clientByChainID:    clientByChainID,
-		rpcClientByChainID: rpcClientByChainID,
-	}, nil
+	return registry, nil
 }
 
 func (h *RpcRegistry) GetClient(chainID int64) (*ethclient.Client, error) {
@@ -64,3 +89,26 @@ func (h *RpcRegistry) GetRpcClient(chainID int64) (*ethrpc.Client, error) {
 
 	return client, nil
 }
+
+// SubscribeNewHeads subscribes to the new heads of chainID through its WS endpoint, the head stream
+// of the chain is started by the first subscription and runs until Close, which closes the subscriptions
+func (h *RpcRegistry) SubscribeNewHeads(chainID int64, bufferSize int) (*HeadSubscription, error) {
+	stream, ok := h.headStreamByChainID[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w for chainID %d", ErrNoWSEndpoint, chainID)
+	}
+
+	sub := stream.Subscribe(bufferSize)
+	stream.Start(h.ctx)
+
+	return sub, nil
+}
+
+// Close stops the health checks and closes the clients
+func (h *RpcRegistry) Close() {
+	h.cancel()
+
+	for _, client := range h.clientByChainID {
+		client.Close()
+	}
+}
@@ -1,10 +1,53 @@
 package rpcregistry
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type Config = map[int64]ChainConfig
 
 type ChainConfig struct {
-	HTTP             string
+	// HTTP is the endpoint of the chain when Endpoints is empty
+	HTTP      string
+	Endpoints []EndpointConfig
+	// WS is the optional WebSocket endpoint new heads are subscribed through
+	WS               string
+	Failover         FailoverConfig
 	MulticallAddress common.Address
 }
+
+type EndpointConfig struct {
+	URL string
+	// Weight is the relative share of requests sent to the endpoint while it is healthy, zero means 1
+	Weight int
+}
+
+// FailoverConfig tunes the health checks and retries across the endpoints of a chain, zero values and nil
+// pointers use the defaults
+type FailoverConfig struct {
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	// MaxBlockLag is how many blocks an endpoint may lag behind the highest endpoint before it is unhealthy,
+	// zero requires every endpoint to be at the highest block
+	MaxBlockLag *uint64
+	// MaxErrorRate is the share of failed requests within a health check interval above which an endpoint is unhealthy
+	MaxErrorRate float64
+	// MaxRetries is how many times a failed read is retried on another endpoint, zero disables retries
+	MaxRetries   *int
+	RetryBackoff time.Duration
+}
+
+// endpoints returns the configured endpoints, falling back to HTTP
+func (c ChainConfig) endpoints() []EndpointConfig {
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints
+	}
+
+	if c.HTTP == "" {
+		return nil
+	}
+
+	return []EndpointConfig{{URL: c.HTTP}}
+}